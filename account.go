@@ -0,0 +1,216 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Account is the Go-representation of Twilio REST API's account, either the
+// main account of the client's credentials or one of its subaccounts.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/iam/api/account
+type Account struct {
+	AuthToken       string `json:"auth_token"`
+	DateCreated     *Time  `json:"date_created"`
+	DateUpdated     *Time  `json:"date_updated"`
+	FriendlyName    string `json:"friendly_name"`
+	OwnerAccountSID string `json:"owner_account_sid"`
+	SID             string `json:"sid"`
+	Status          string `json:"status"`
+	SubresourceURIs struct {
+		Calls                string `json:"calls"`
+		Conferences          string `json:"conferences"`
+		IncomingPhoneNumbers string `json:"incoming_phone_numbers"`
+		Messages             string `json:"messages"`
+		Notifications        string `json:"notifications"`
+		Queues               string `json:"queues"`
+		Recordings           string `json:"recordings"`
+		Transcriptions       string `json:"transcriptions"`
+	} `json:"subresource_uris"`
+	Type string `json:"type"`
+	URI  string `json:"uri"`
+}
+
+// AccountStatus is the status of an account
+type AccountStatus string
+
+// Account statuses. A suspended account can be reactivated, a closed account
+// is deleted for good after 30 days.
+const (
+	AccountActive    AccountStatus = "active"
+	AccountSuspended AccountStatus = "suspended"
+	AccountClosed    AccountStatus = "closed"
+)
+
+func (c *Client) accountsURL() string {
+	return fmt.Sprintf("%s/%s/Accounts.json", c.restURL(), APIVersion)
+}
+
+func (c *Client) accountURL(sid string) string {
+	return fmt.Sprintf("%s/%s/Accounts/%s.json", c.restURL(), APIVersion, sid)
+}
+
+// AccountListQuery is a struct that contains an embedded utwil.ListQuery.
+// The typing allows the correctly-typed iterator/list to be returned.
+type AccountListQuery struct{ *ListQuery }
+
+// Accounts takes a vargs of utwil.ListQueryConf functions to configure a
+// query for the account of the client's credentials and its subaccounts:
+//
+//	iter := client.Accounts(utwil.Status(string(utwil.AccountActive))).Iter()
+//	var account utwil.Account
+//	for iter.Next(&account) {
+//		tenant := client.Subaccount(account.SID)
+//		// use tenant
+//	}
+func (c *Client) Accounts(confs ...ListQueryConf) *AccountListQuery {
+	return &AccountListQuery{ListQuery: newListQuery(c, confs...)}
+}
+
+// Iter creates an iterator that iterates utwil.Account results
+func (q *AccountListQuery) Iter() *AccountIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as AccountListQuery.Iter, but every page is fetched
+// with ctx and iteration stops once ctx is done.
+func (q *AccountListQuery) IterContext(ctx context.Context) *AccountIter {
+	initURI := fmt.Sprintf("%s?%s", q.accountsURL(), q.Values.Encode())
+	iter := &AccountIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &accountList{}
+	return iter
+}
+
+type accountList struct {
+	Accounts []Account `json:"accounts"`
+	listResource
+}
+
+func (al accountList) item(idx int) interface{} { return al.Accounts[idx] }
+func (al accountList) size() int                { return len(al.Accounts) }
+func (al accountList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return al.loadNextPage(ctx, c, &accountList{})
+}
+
+// GetAccount fetches the account with the given SID. A *NotFoundError is
+// returned if there is no such account.
+func (c *Client) GetAccount(sid string) (*Account, error) {
+	return c.GetAccountContext(context.Background(), sid)
+}
+
+// GetAccountContext is the same as Client.GetAccount, but the request is
+// bound to ctx.
+func (c *Client) GetAccountContext(ctx context.Context, sid string) (*Account, error) {
+	account := &Account{}
+	if err := c.getJSON(ctx, c.accountURL(sid), account); err != nil {
+		return nil, notFound(err, "Account", sid)
+	}
+	return account, nil
+}
+
+// CreateSubaccount creates a subaccount of the account of the client's
+// credentials. Twilio names it after its creation time if friendlyName is
+// empty.
+//
+// Example:
+//
+//	account, err := client.CreateSubaccount("Tenant 42")
+//	// handle err
+//	tenant := client.Subaccount(account.SID)
+func (c *Client) CreateSubaccount(friendlyName string) (*Account, error) {
+	return c.CreateSubaccountContext(context.Background(), friendlyName)
+}
+
+// CreateSubaccountContext is the same as Client.CreateSubaccount, but the
+// request is bound to ctx.
+func (c *Client) CreateSubaccountContext(ctx context.Context, friendlyName string) (*Account, error) {
+	values := url.Values{}
+	if friendlyName != "" {
+		values.Set("FriendlyName", friendlyName)
+	}
+	account := &Account{}
+	if err := c.postForm(ctx, c.accountsURL(), values, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// AccountUpdate is the Go-representation of the Twilio REST API's request to
+// modify an account.
+type AccountUpdate struct {
+	FriendlyName string
+	Status       AccountStatus
+}
+
+// UpdateAccount modifies the account with the given SID, populating form
+// fields only if they contain a non-zero value, and returns the updated
+// account.
+func (c *Client) UpdateAccount(sid string, update AccountUpdate) (*Account, error) {
+	return c.UpdateAccountContext(context.Background(), sid, update)
+}
+
+// UpdateAccountContext is the same as Client.UpdateAccount, but the request
+// is bound to ctx.
+func (c *Client) UpdateAccountContext(ctx context.Context, sid string, update AccountUpdate) (*Account, error) {
+	values := url.Values{}
+	if update.FriendlyName != "" {
+		values.Set("FriendlyName", update.FriendlyName)
+	}
+	if update.Status != "" {
+		values.Set("Status", string(update.Status))
+	}
+	account := &Account{}
+	if err := c.postForm(ctx, c.accountURL(sid), values, account); err != nil {
+		return nil, notFound(err, "Account", sid)
+	}
+	return account, nil
+}
+
+// RenameAccount changes the friendly name of the account.
+func (c *Client) RenameAccount(sid, friendlyName string) (*Account, error) {
+	return c.RenameAccountContext(context.Background(), sid, friendlyName)
+}
+
+// RenameAccountContext is the same as Client.RenameAccount, but the request
+// is bound to ctx.
+func (c *Client) RenameAccountContext(ctx context.Context, sid, friendlyName string) (*Account, error) {
+	return c.UpdateAccountContext(ctx, sid, AccountUpdate{FriendlyName: friendlyName})
+}
+
+// SuspendAccount suspends the subaccount, which rejects API requests and
+// stops handling calls and messages until it is reactivated.
+func (c *Client) SuspendAccount(sid string) (*Account, error) {
+	return c.SuspendAccountContext(context.Background(), sid)
+}
+
+// SuspendAccountContext is the same as Client.SuspendAccount, but the request
+// is bound to ctx.
+func (c *Client) SuspendAccountContext(ctx context.Context, sid string) (*Account, error) {
+	return c.UpdateAccountContext(ctx, sid, AccountUpdate{Status: AccountSuspended})
+}
+
+// ReactivateAccount reactivates a suspended subaccount.
+func (c *Client) ReactivateAccount(sid string) (*Account, error) {
+	return c.ReactivateAccountContext(context.Background(), sid)
+}
+
+// ReactivateAccountContext is the same as Client.ReactivateAccount, but the
+// request is bound to ctx.
+func (c *Client) ReactivateAccountContext(ctx context.Context, sid string) (*Account, error) {
+	return c.UpdateAccountContext(ctx, sid, AccountUpdate{Status: AccountActive})
+}
+
+// CloseAccount closes the subaccount for good, releasing its phone numbers.
+// It cannot be reactivated.
+func (c *Client) CloseAccount(sid string) (*Account, error) {
+	return c.CloseAccountContext(context.Background(), sid)
+}
+
+// CloseAccountContext is the same as Client.CloseAccount, but the request is
+// bound to ctx.
+func (c *Client) CloseAccountContext(ctx context.Context, sid string) (*Account, error) {
+	return c.UpdateAccountContext(ctx, sid, AccountUpdate{Status: AccountClosed})
+}
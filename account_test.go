@@ -0,0 +1,77 @@
+package utwil
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSubaccounts(t *testing.T) {
+	srv := requireFake(t)
+	account, err := TestClient.CreateSubaccount("Tenant 42")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if account.FriendlyName != "Tenant 42" || account.OwnerAccountSID != TestClient.AccountSID ||
+		AccountStatus(account.Status) != AccountActive || account.AuthToken == "" {
+		t.Fatalf("unexpected account: %+v", account)
+	}
+	if account, err = TestClient.RenameAccount(account.SID, "Tenant 42 (Acme)"); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+
+	iter := TestClient.Accounts(FriendlyName("Tenant 42 (Acme)")).Iter()
+	var listed Account
+	if !iter.Next(&listed) || listed.SID != account.SID || iter.Next(&listed) {
+		t.Fatalf("unexpected accounts: %v", iter.Err())
+	}
+	main, err := TestClient.GetAccount(TestClient.AccountSID)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if main.SID != TestClient.AccountSID {
+		t.Fatalf("unexpected account: %+v", main)
+	}
+
+	tenant := TestClient.Subaccount(account.SID)
+	if _, err := tenant.SendSMS(FromPhoneNumber, ToPhoneNumber, "Hello, tenant!"); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	req := srv.AssertRequested(t, "POST", "/Messages.json")
+	if req.Path != "/"+APIVersion+"/Accounts/"+account.SID+"/Messages.json" {
+		t.Fatalf("unexpected path: %s", req.Path)
+	}
+	if user, _, _ := (&http.Request{Header: req.Header}).BasicAuth(); user != TestClient.AccountSID {
+		t.Fatalf("authenticated as %s instead of the parent account", user)
+	}
+	for _, msg := range srv.List("Messages") {
+		if msg["body"] == "Hello, tenant!" {
+			t.Fatalf("message was sent on behalf of the parent account")
+		}
+	}
+
+	if account, err = TestClient.SuspendAccount(account.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if AccountStatus(account.Status) != AccountSuspended {
+		t.Fatalf("unexpected account: %+v", account)
+	}
+	if _, err := tenant.SendSMS(FromPhoneNumber, ToPhoneNumber, "Hello, tenant!"); err == nil {
+		t.Fatalf("suspended account sent a message")
+	}
+	if _, err = TestClient.ReactivateAccount(account.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if account, err = TestClient.CloseAccount(account.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if AccountStatus(account.Status) != AccountClosed {
+		t.Fatalf("unexpected account: %+v", account)
+	}
+
+	if _, err := TestClient.UpdateAccount(TestClient.AccountSID, AccountUpdate{Status: AccountClosed}); err == nil {
+		t.Fatalf("closing the account of the credentials was accepted")
+	}
+	if _, err := TestClient.GetAccount("AC00000000000000000000000000000000"); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
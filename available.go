@@ -0,0 +1,167 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// AvailablePhoneNumber is the Go-representation of Twilio REST API's
+// available phone number, a number in Twilio's inventory that can be bought
+// with Client.PurchasePhoneNumber.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/phone-numbers/api/availablephonenumber-resource
+type AvailablePhoneNumber struct {
+	AddressRequirements string       `json:"address_requirements"`
+	Beta                bool         `json:"beta"`
+	Capabilities        Capabilities `json:"capabilities"`
+	FriendlyName        string       `json:"friendly_name"`
+	ISOCountry          string       `json:"iso_country"`
+	LATA                string       `json:"lata"`
+	Latitude            string       `json:"latitude"`
+	Locality            string       `json:"locality"`
+	Longitude           string       `json:"longitude"`
+	PhoneNumber         string       `json:"phone_number"`
+	PostalCode          string       `json:"postal_code"`
+	RateCenter          string       `json:"rate_center"`
+	Region              string       `json:"region"`
+}
+
+func (c *Client) availablePhoneNumbersURL(country string, numberType PhoneNumberType) string {
+	return fmt.Sprintf("%s/AvailablePhoneNumbers/%s/%s.json", c.urlPrefix(), country, numberType)
+}
+
+// AvailablePhoneNumberListQuery is a struct that contains an embedded
+// utwil.ListQuery. The typing allows the correctly-typed iterator/list to be
+// returned.
+type AvailablePhoneNumberListQuery struct {
+	*ListQuery
+	country    string
+	numberType PhoneNumberType
+}
+
+// AvailablePhoneNumbers takes an ISO country code, e.g. "US", a type of
+// phone number and a vargs of utwil.ListQueryConf functions to configure a
+// search of Twilio's inventory:
+//
+//	iter := client.AvailablePhoneNumbers("US", utwil.PhoneNumberLocal,
+//		utwil.AreaCode("510"),
+//		utwil.Contains("555****"),
+//		utwil.SMSEnabled(true)).Iter()
+//	var available utwil.AvailablePhoneNumber
+//	if iter.Next(&available) {
+//		number, err := client.PurchasePhoneNumber(utwil.IncomingPhoneNumberReq{
+//			PhoneNumber: available.PhoneNumber,
+//		})
+//	}
+//
+// Twilio returns at most one page of results, which PageSize can enlarge.
+func (c *Client) AvailablePhoneNumbers(country string, numberType PhoneNumberType, confs ...ListQueryConf) *AvailablePhoneNumberListQuery {
+	return &AvailablePhoneNumberListQuery{
+		ListQuery:  newListQuery(c, confs...),
+		country:    country,
+		numberType: numberType,
+	}
+}
+
+// AreaCode filters available phone numbers in the given area code. It only
+// applies to the US and Canada.
+func AreaCode(code string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("AreaCode", code) }
+}
+
+// Contains filters available phone numbers that match a pattern of digits,
+// letters (matching their keypad digits) and "*" wildcards, e.g. "510555****"
+// or "STORM".
+func Contains(pattern string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("Contains", pattern) }
+}
+
+// InRegion filters available phone numbers in the given region, e.g. the
+// state "CA". It only applies to the US and Canada.
+func InRegion(region string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("InRegion", region) }
+}
+
+// InPostalCode filters available phone numbers in the given postal code. It
+// only applies to the US and Canada.
+func InPostalCode(postalCode string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("InPostalCode", postalCode) }
+}
+
+// InLocality filters available phone numbers in the given city or town.
+func InLocality(locality string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("InLocality", locality) }
+}
+
+// NearNumber filters available phone numbers geographically close to the
+// given phone number, within Distance. It only applies to the US and Canada.
+func NearNumber(phoneNumber string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("NearNumber", phoneNumber) }
+}
+
+// NearLatLong filters available phone numbers geographically close to the
+// given coordinates, within Distance. It only applies to the US and Canada.
+func NearLatLong(latitude, longitude float64) ListQueryConf {
+	return func(q *ListQuery) {
+		q.Values.Set("NearLatLong", strconv.FormatFloat(latitude, 'f', -1, 64)+","+
+			strconv.FormatFloat(longitude, 'f', -1, 64))
+	}
+}
+
+// Distance sets the search radius in miles of NearNumber and NearLatLong,
+// which Twilio defaults to 25.
+func Distance(miles int) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("Distance", strconv.Itoa(miles)) }
+}
+
+// VoiceEnabled filters available phone numbers by whether they can make and
+// receive calls.
+func VoiceEnabled(enabled bool) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("VoiceEnabled", strconv.FormatBool(enabled)) }
+}
+
+// SMSEnabled filters available phone numbers by whether they can send and
+// receive SMS.
+func SMSEnabled(enabled bool) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("SmsEnabled", strconv.FormatBool(enabled)) }
+}
+
+// MMSEnabled filters available phone numbers by whether they can send and
+// receive MMS.
+func MMSEnabled(enabled bool) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("MmsEnabled", strconv.FormatBool(enabled)) }
+}
+
+// FaxEnabled filters available phone numbers by whether they can send and
+// receive faxes.
+func FaxEnabled(enabled bool) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("FaxEnabled", strconv.FormatBool(enabled)) }
+}
+
+// Iter creates an iterator that iterates utwil.AvailablePhoneNumber results
+func (q *AvailablePhoneNumberListQuery) Iter() *AvailablePhoneNumberIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as AvailablePhoneNumberListQuery.Iter, but every
+// page is fetched with ctx and iteration stops once ctx is done.
+func (q *AvailablePhoneNumberListQuery) IterContext(ctx context.Context) *AvailablePhoneNumberIter {
+	initURI := fmt.Sprintf("%s?%s", q.availablePhoneNumbersURL(q.country, q.numberType), q.Values.Encode())
+	iter := &AvailablePhoneNumberIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &availablePhoneNumberList{}
+	return iter
+}
+
+type availablePhoneNumberList struct {
+	AvailablePhoneNumbers []AvailablePhoneNumber `json:"available_phone_numbers"`
+	listResource
+}
+
+func (al availablePhoneNumberList) item(idx int) interface{} { return al.AvailablePhoneNumbers[idx] }
+func (al availablePhoneNumberList) size() int                { return len(al.AvailablePhoneNumbers) }
+func (al availablePhoneNumberList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return al.loadNextPage(ctx, c, &availablePhoneNumberList{})
+}
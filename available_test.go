@@ -0,0 +1,78 @@
+package utwil
+
+import (
+	"testing"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestAvailablePhoneNumbers(t *testing.T) {
+	srv := requireFake(t)
+	inventory := "AvailablePhoneNumbers/US/Local"
+	srv.Add(inventory, utwiltest.Resource{
+		"phone_number": "+15105557867", // 555-STOR
+		"region":       "CA",
+		"postal_code":  "94703",
+		"latitude":     "37.857",
+		"longitude":    "-122.27",
+		"capabilities": utwiltest.Resource{"voice": true, "SMS": true, "MMS": true},
+	})
+	srv.Add(inventory, utwiltest.Resource{
+		"phone_number": "+15105550100",
+		"region":       "CA",
+		"latitude":     "37.857",
+		"longitude":    "-122.27",
+		"capabilities": utwiltest.Resource{"voice": true, "SMS": false, "MMS": false},
+	})
+	srv.Add(inventory, utwiltest.Resource{
+		"phone_number": "+12125557867",
+		"region":       "NY",
+		"latitude":     "40.71",
+		"longitude":    "-74.0",
+		"capabilities": utwiltest.Resource{"voice": true, "SMS": true, "MMS": true},
+	})
+	srv.Add("AvailablePhoneNumbers/US/TollFree", utwiltest.Resource{"phone_number": "+18005557867"})
+
+	for _, test := range []struct {
+		confs    []ListQueryConf
+		expected []string
+	}{
+		{[]ListQueryConf{AreaCode("510")}, []string{"+15105550100", "+15105557867"}},
+		{[]ListQueryConf{Contains("STOR")}, []string{"+12125557867", "+15105557867"}},
+		{[]ListQueryConf{Contains("510555****"), SMSEnabled(true)}, []string{"+15105557867"}},
+		{[]ListQueryConf{InRegion("NY")}, []string{"+12125557867"}},
+		{[]ListQueryConf{InPostalCode("94703")}, []string{"+15105557867"}},
+		{[]ListQueryConf{NearLatLong(37.87, -122.26), Distance(10)}, []string{"+15105550100", "+15105557867"}},
+		{[]ListQueryConf{MMSEnabled(false)}, []string{"+15105550100"}},
+	} {
+		query := TestClient.AvailablePhoneNumbers("US", PhoneNumberLocal, test.confs...)
+		iter := query.Iter()
+		var number AvailablePhoneNumber
+		var found []string
+		for iter.Next(&number) {
+			found = append(found, number.PhoneNumber)
+		}
+		if iter.Err() != nil {
+			t.Fatalf("error: %s", iter.Err().Error())
+		}
+		if len(found) != len(test.expected) {
+			t.Fatalf("%s: expected %v, got %v", query.Values.Encode(), test.expected, found)
+		}
+		for i := range found {
+			if found[i] != test.expected[i] {
+				t.Fatalf("%s: expected %v, got %v", query.Values.Encode(), test.expected, found)
+			}
+		}
+	}
+
+	req := srv.AssertRequested(t, "GET", "/AvailablePhoneNumbers/US/Local.json")
+	if req.Query.Get("MmsEnabled") != "false" {
+		t.Fatalf("unexpected query: %v", req.Query)
+	}
+
+	iter := TestClient.AvailablePhoneNumbers("US", PhoneNumberTollFree, Contains("STOR")).Iter()
+	var number AvailablePhoneNumber
+	if !iter.Next(&number) || number.PhoneNumber != "+18005557867" || iter.Next(&number) {
+		t.Fatalf("unexpected toll-free numbers: %v", iter.Err())
+	}
+}
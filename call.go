@@ -1,6 +1,7 @@
 package utwil
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -51,24 +52,76 @@ type Call struct {
 //	https://www.twilio.com/docs/api/rest/making-calls
 //
 type CallReq struct {
-	From                 string
-	To                   string
-	URL                  string
-	ApplicationSID       string
-	Method               string
-	FallbackURL          string
-	FallbackMethod       string
-	StatusCallback       string
-	StatusCallbackMethod string
-	SendDigits           string
-	IfMachine            string
-	Timeout              int
-	Record               bool
+	From                               string
+	To                                 string
+	URL                                string
+	Twiml                              string
+	ApplicationSID                     string
+	Method                             string
+	FallbackURL                        string
+	FallbackMethod                     string
+	StatusCallback                     string
+	StatusCallbackEvent                []string
+	StatusCallbackMethod               string
+	SendDigits                         string
+	Timeout                            int
+	TimeLimit                          int
+	Record                             bool
+	RecordingChannels                  string
+	RecordingStatusCallback            string
+	RecordingStatusCallbackMethod      string
+	RecordingStatusCallbackEvent       []string
+	Trim                               string
+	MachineDetection                   string
+	MachineDetectionTimeout            int
+	MachineDetectionSpeechThreshold    int
+	MachineDetectionSpeechEndThreshold int
+	MachineDetectionSilenceTimeout     int
+	AsyncAMD                           bool
+	AsyncAMDStatusCallback             string
+	AsyncAMDStatusCallbackMethod       string
+	CallerID                           string
+	SIPAuthUsername                    string
+	SIPAuthPassword                    string
+	CallReason                         string
+
+	// Deprecated: IfMachine is no longer supported by Twilio; use
+	// MachineDetection instead.
+	IfMachine string
+}
+
+// Validate checks the request for errors Twilio would reject it for, so they
+// are caught before anything is sent. SubmitCall calls it first.
+func (req CallReq) Validate() error {
+	instructions := 0
+	for _, field := range []string{req.URL, req.Twiml, req.ApplicationSID} {
+		if field != "" {
+			instructions++
+		}
+	}
+	switch {
+	case instructions != 1:
+		return fmt.Errorf("utwil: exactly one of URL, Twiml or ApplicationSID is required")
+	case req.AsyncAMD && req.MachineDetection == "":
+		return fmt.Errorf("utwil: AsyncAMD requires MachineDetection")
+	case req.IfMachine != "" && req.MachineDetection != "":
+		return fmt.Errorf("utwil: IfMachine and MachineDetection are mutually exclusive")
+	}
+	return nil
 }
 
-// SubmitCall sends a call request populating form fields only if they contain
-// a non-zero value.
+// SubmitCall validates and sends a call request populating form fields only
+// if they contain a non-zero value.
 func (c *Client) SubmitCall(req CallReq) (*Call, error) {
+	return c.SubmitCallContext(context.Background(), req)
+}
+
+// SubmitCallContext is the same as Client.SubmitCall, but the request is
+// bound to ctx.
+func (c *Client) SubmitCallContext(ctx context.Context, req CallReq) (*Call, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	// @TODO wait until github.com/gorilla/schema supports struct-to-url.Values
 	values := url.Values{}
 	values.Set("From", req.From)
@@ -76,6 +129,9 @@ func (c *Client) SubmitCall(req CallReq) (*Call, error) {
 	if req.URL != "" {
 		values.Set("Url", req.URL)
 	}
+	if req.Twiml != "" {
+		values.Set("Twiml", req.Twiml)
+	}
 	if req.ApplicationSID != "" {
 		values.Set("ApplicationSid", req.ApplicationSID)
 	}
@@ -91,6 +147,9 @@ func (c *Client) SubmitCall(req CallReq) (*Call, error) {
 	if req.StatusCallback != "" {
 		values.Set("StatusCallback", req.StatusCallback)
 	}
+	for _, event := range req.StatusCallbackEvent {
+		values.Add("StatusCallbackEvent", event)
+	}
 	if req.StatusCallbackMethod != "" {
 		values.Set("StatusCallbackMethod", req.StatusCallbackMethod)
 	}
@@ -103,11 +162,68 @@ func (c *Client) SubmitCall(req CallReq) (*Call, error) {
 	if req.Timeout > 0 {
 		values.Set("Timeout", strconv.Itoa(req.Timeout))
 	}
+	if req.TimeLimit > 0 {
+		values.Set("TimeLimit", strconv.Itoa(req.TimeLimit))
+	}
 	if req.Record {
 		values.Set("Record", "true")
 	}
+	if req.RecordingChannels != "" {
+		values.Set("RecordingChannels", req.RecordingChannels)
+	}
+	if req.RecordingStatusCallback != "" {
+		values.Set("RecordingStatusCallback", req.RecordingStatusCallback)
+	}
+	if req.RecordingStatusCallbackMethod != "" {
+		values.Set("RecordingStatusCallbackMethod", req.RecordingStatusCallbackMethod)
+	}
+	for _, event := range req.RecordingStatusCallbackEvent {
+		values.Add("RecordingStatusCallbackEvent", event)
+	}
+	if req.Trim != "" {
+		values.Set("Trim", req.Trim)
+	}
+	if req.MachineDetection != "" {
+		values.Set("MachineDetection", req.MachineDetection)
+	}
+	if req.MachineDetectionTimeout > 0 {
+		values.Set("MachineDetectionTimeout", strconv.Itoa(req.MachineDetectionTimeout))
+	}
+	if req.MachineDetectionSpeechThreshold > 0 {
+		values.Set("MachineDetectionSpeechThreshold", strconv.Itoa(req.MachineDetectionSpeechThreshold))
+	}
+	if req.MachineDetectionSpeechEndThreshold > 0 {
+		values.Set("MachineDetectionSpeechEndThreshold", strconv.Itoa(req.MachineDetectionSpeechEndThreshold))
+	}
+	if req.MachineDetectionSilenceTimeout > 0 {
+		values.Set("MachineDetectionSilenceTimeout", strconv.Itoa(req.MachineDetectionSilenceTimeout))
+	}
+	if req.AsyncAMD {
+		values.Set("AsyncAmd", "true")
+	}
+	if req.AsyncAMDStatusCallback != "" {
+		values.Set("AsyncAmdStatusCallback", req.AsyncAMDStatusCallback)
+	}
+	if req.AsyncAMDStatusCallbackMethod != "" {
+		values.Set("AsyncAmdStatusCallbackMethod", req.AsyncAMDStatusCallbackMethod)
+	}
+	if req.CallerID != "" {
+		values.Set("CallerId", req.CallerID)
+	}
+	if req.SIPAuthUsername != "" {
+		values.Set("SipAuthUsername", req.SIPAuthUsername)
+	}
+	if req.SIPAuthPassword != "" {
+		values.Set("SipAuthPassword", req.SIPAuthPassword)
+	}
+	if req.CallReason != "" {
+		values.Set("CallReason", req.CallReason)
+	}
+	if err := c.throttle(ctx, req.From); err != nil {
+		return nil, err
+	}
 	call := &Call{}
-	err := c.postForm(fmt.Sprintf("%s/Calls.json", c.urlPrefix()), values, call)
+	err := c.postForm(ctx, c.callsURL(), values, call)
 	return call, err
 }
 
@@ -125,23 +241,166 @@ func (c *Client) SubmitCall(req CallReq) (*Call, error) {
 //      call, err := client.Call("+15551231234", "+15553214321", callbackPostURL)
 //
 func (c *Client) Call(from, to, callbackPostURL string) (*Call, error) {
+	return c.CallContext(context.Background(), from, to, callbackPostURL)
+}
+
+// CallContext is the same as Client.Call, but the request is bound to ctx.
+func (c *Client) CallContext(ctx context.Context, from, to, callbackPostURL string) (*Call, error) {
 	req := CallReq{
 		From: from,
 		To:   to,
 		URL:  callbackPostURL,
 	}
-	return c.SubmitCall(req)
+	return c.SubmitCallContext(ctx, req)
 }
 
 // RecordedCall is the same as Client.Call, but recorded
 func (c *Client) RecordedCall(from, to, callbackPostURL string) (*Call, error) {
+	return c.RecordedCallContext(context.Background(), from, to, callbackPostURL)
+}
+
+// RecordedCallContext is the same as Client.RecordedCall, but the request is
+// bound to ctx.
+func (c *Client) RecordedCallContext(ctx context.Context, from, to, callbackPostURL string) (*Call, error) {
 	req := CallReq{
 		From:   from,
 		To:     to,
 		URL:    callbackPostURL,
 		Record: true,
 	}
-	return c.SubmitCall(req)
+	return c.SubmitCallContext(ctx, req)
+}
+
+// GetCall fetches the call with the given SID, e.g. to refresh the status of
+// a call returned by SubmitCall. A *NotFoundError is returned if there is no
+// such call.
+func (c *Client) GetCall(sid string) (*Call, error) {
+	return c.GetCallContext(context.Background(), sid)
+}
+
+// GetCallContext is the same as Client.GetCall, but the request is bound to
+// ctx.
+func (c *Client) GetCallContext(ctx context.Context, sid string) (*Call, error) {
+	call := &Call{}
+	if err := c.getJSON(ctx, c.callURL(sid), call); err != nil {
+		return nil, notFound(err, "Call", sid)
+	}
+	return call, nil
+}
+
+// CallUpdate is the Go-representation of the Twilio REST API's request to
+// modify a live call: redirect it to new TwiML with URL or Twiml, or end it
+// with Status.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/call-resource#update-a-call-resource
+//
+type CallUpdate struct {
+	URL                  string
+	Method               string
+	Twiml                string
+	Status               CallStatus
+	FallbackURL          string
+	FallbackMethod       string
+	StatusCallback       string
+	StatusCallbackMethod string
+	TimeLimit            int
+}
+
+// Validate checks the update for errors Twilio would reject it for, so they
+// are caught before anything is sent. UpdateCall calls it first.
+func (update CallUpdate) Validate() error {
+	if update.URL != "" && update.Twiml != "" {
+		return fmt.Errorf("utwil: URL and Twiml are mutually exclusive")
+	}
+	return nil
+}
+
+// UpdateCall modifies the live call with the given SID, populating form
+// fields only if they contain a non-zero value, and returns the updated call.
+func (c *Client) UpdateCall(sid string, update CallUpdate) (*Call, error) {
+	return c.UpdateCallContext(context.Background(), sid, update)
+}
+
+// UpdateCallContext is the same as Client.UpdateCall, but the request is
+// bound to ctx.
+func (c *Client) UpdateCallContext(ctx context.Context, sid string, update CallUpdate) (*Call, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	values := url.Values{}
+	if update.URL != "" {
+		values.Set("Url", update.URL)
+	}
+	if update.Method != "" {
+		values.Set("Method", update.Method)
+	}
+	if update.Twiml != "" {
+		values.Set("Twiml", update.Twiml)
+	}
+	if update.Status != "" {
+		values.Set("Status", string(update.Status))
+	}
+	if update.FallbackURL != "" {
+		values.Set("FallbackUrl", update.FallbackURL)
+	}
+	if update.FallbackMethod != "" {
+		values.Set("FallbackMethod", update.FallbackMethod)
+	}
+	if update.StatusCallback != "" {
+		values.Set("StatusCallback", update.StatusCallback)
+	}
+	if update.StatusCallbackMethod != "" {
+		values.Set("StatusCallbackMethod", update.StatusCallbackMethod)
+	}
+	if update.TimeLimit > 0 {
+		values.Set("TimeLimit", strconv.Itoa(update.TimeLimit))
+	}
+	call := &Call{}
+	if err := c.postForm(ctx, c.callURL(sid), values, call); err != nil {
+		return nil, notFound(err, "Call", sid)
+	}
+	return call, nil
+}
+
+// RedirectCall makes the live call continue with the TwiML at url.
+//
+// Example:
+//
+//	call, err := client.RedirectCall(call.SID, "https://example.com/hold-music.twiml")
+//
+func (c *Client) RedirectCall(sid, url string) (*Call, error) {
+	return c.RedirectCallContext(context.Background(), sid, url)
+}
+
+// RedirectCallContext is the same as Client.RedirectCall, but the request is
+// bound to ctx.
+func (c *Client) RedirectCallContext(ctx context.Context, sid, url string) (*Call, error) {
+	return c.UpdateCallContext(ctx, sid, CallUpdate{URL: url})
+}
+
+// HangupCall ends the call, whether it is ringing or in progress.
+func (c *Client) HangupCall(sid string) (*Call, error) {
+	return c.HangupCallContext(context.Background(), sid)
+}
+
+// HangupCallContext is the same as Client.HangupCall, but the request is
+// bound to ctx.
+func (c *Client) HangupCallContext(ctx context.Context, sid string) (*Call, error) {
+	return c.UpdateCallContext(ctx, sid, CallUpdate{Status: CallCompleted})
+}
+
+// CancelCall cancels the call if it is still queued or ringing, leaving
+// calls in progress untouched.
+func (c *Client) CancelCall(sid string) (*Call, error) {
+	return c.CancelCallContext(context.Background(), sid)
+}
+
+// CancelCallContext is the same as Client.CancelCall, but the request is
+// bound to ctx.
+func (c *Client) CancelCallContext(ctx context.Context, sid string) (*Call, error) {
+	return c.UpdateCallContext(ctx, sid, CallUpdate{Status: CallCanceled})
 }
 
 // CallListQuery is a struct that contains an embedded utwil.ListQuery.
@@ -183,8 +442,14 @@ func StartedAfterYMD(t time.Time) ListQueryConf {
 
 // Iter creates an iterator that iterates utwil.Call results
 func (q *CallListQuery) Iter() *CallIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as CallListQuery.Iter, but every page is fetched
+// with ctx and iteration stops once ctx is done.
+func (q *CallListQuery) IterContext(ctx context.Context) *CallIter {
 	initURI := fmt.Sprintf("%s?%s", q.callsURL(), q.Values.Encode())
-	iter := &CallIter{iter: newIter(q.Client, initURI)}
+	iter := &CallIter{iter: newIter(ctx, q.Client, initURI)}
 	iter.iterable = &callList{}
 	return iter
 }
@@ -196,6 +461,6 @@ type callList struct {
 
 func (cl callList) item(idx int) interface{} { return cl.Calls[idx] }
 func (cl callList) size() int                { return len(cl.Calls) }
-func (cl callList) nextPage(c *Client) (iterable, error) {
-	return cl.loadNextPage(c, &callList{})
+func (cl callList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return cl.loadNextPage(ctx, c, &callList{})
 }
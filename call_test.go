@@ -3,7 +3,10 @@ package utwil
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/wyc/utwil/utwiltest"
 )
 
 // This test calls ToPhoneNumber and also forwards the call to ToPhoneNumber.
@@ -20,3 +23,150 @@ func TestCall(t *testing.T) {
 	}
 	t.Logf("Call:\n%s\n", string(bs))
 }
+
+func TestGetCall(t *testing.T) {
+	srv := requireFake(t)
+	seeded := srv.Add("Calls", utwiltest.Resource{"status": "in-progress", "to": ToPhoneNumber})
+	call, err := TestClient.GetCall(seeded["sid"].(string))
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if call.SID != seeded["sid"] || CallStatus(call.Status) != CallInProgress {
+		t.Fatalf("unexpected call: %+v", call)
+	}
+
+	_, err = TestClient.GetCall("CA00000000000000000000000000000000")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
+
+// Every optional CallReq field is sent under Twilio's parameter name
+func TestSubmitCallParams(t *testing.T) {
+	srv := requireFake(t)
+	for _, test := range []struct {
+		name  string
+		conf  func(*CallReq)
+		param string
+		want  []string
+	}{
+		{"Twiml", func(r *CallReq) { r.URL, r.Twiml = "", "<Response><Say>Hi</Say></Response>" },
+			"Twiml", []string{"<Response><Say>Hi</Say></Response>"}},
+		{"ApplicationSID", func(r *CallReq) { r.URL, r.ApplicationSID = "", "AP123" },
+			"ApplicationSid", []string{"AP123"}},
+		{"StatusCallbackEvent", func(r *CallReq) { r.StatusCallbackEvent = []string{"initiated", "completed"} },
+			"StatusCallbackEvent", []string{"initiated", "completed"}},
+		{"TimeLimit", func(r *CallReq) { r.TimeLimit = 600 }, "TimeLimit", []string{"600"}},
+		{"RecordingChannels", func(r *CallReq) { r.Record, r.RecordingChannels = true, "dual" },
+			"RecordingChannels", []string{"dual"}},
+		{"RecordingStatusCallback", func(r *CallReq) { r.RecordingStatusCallback = "https://example.com/rec" },
+			"RecordingStatusCallback", []string{"https://example.com/rec"}},
+		{"RecordingStatusCallbackMethod", func(r *CallReq) { r.RecordingStatusCallbackMethod = "GET" },
+			"RecordingStatusCallbackMethod", []string{"GET"}},
+		{"RecordingStatusCallbackEvent", func(r *CallReq) {
+			r.RecordingStatusCallbackEvent = []string{"in-progress", "completed"}
+		}, "RecordingStatusCallbackEvent", []string{"in-progress", "completed"}},
+		{"Trim", func(r *CallReq) { r.Trim = "trim-silence" }, "Trim", []string{"trim-silence"}},
+		{"MachineDetection", func(r *CallReq) { r.MachineDetection = "DetectMessageEnd" },
+			"MachineDetection", []string{"DetectMessageEnd"}},
+		{"MachineDetectionTimeout", func(r *CallReq) { r.MachineDetectionTimeout = 15 },
+			"MachineDetectionTimeout", []string{"15"}},
+		{"MachineDetectionSpeechThreshold", func(r *CallReq) { r.MachineDetectionSpeechThreshold = 2400 },
+			"MachineDetectionSpeechThreshold", []string{"2400"}},
+		{"MachineDetectionSpeechEndThreshold", func(r *CallReq) { r.MachineDetectionSpeechEndThreshold = 1200 },
+			"MachineDetectionSpeechEndThreshold", []string{"1200"}},
+		{"MachineDetectionSilenceTimeout", func(r *CallReq) { r.MachineDetectionSilenceTimeout = 5000 },
+			"MachineDetectionSilenceTimeout", []string{"5000"}},
+		{"AsyncAMD", func(r *CallReq) { r.MachineDetection, r.AsyncAMD = "Enable", true },
+			"AsyncAmd", []string{"true"}},
+		{"AsyncAMDStatusCallback", func(r *CallReq) {
+			r.MachineDetection, r.AsyncAMD, r.AsyncAMDStatusCallback = "Enable", true, "https://example.com/amd"
+		}, "AsyncAmdStatusCallback", []string{"https://example.com/amd"}},
+		{"AsyncAMDStatusCallbackMethod", func(r *CallReq) {
+			r.MachineDetection, r.AsyncAMD, r.AsyncAMDStatusCallbackMethod = "Enable", true, "GET"
+		}, "AsyncAmdStatusCallbackMethod", []string{"GET"}},
+		{"CallerID", func(r *CallReq) { r.CallerID = "+15550004444" }, "CallerId", []string{"+15550004444"}},
+		{"SIPAuthUsername", func(r *CallReq) { r.SIPAuthUsername = "alice" },
+			"SipAuthUsername", []string{"alice"}},
+		{"SIPAuthPassword", func(r *CallReq) { r.SIPAuthPassword = "secret" },
+			"SipAuthPassword", []string{"secret"}},
+		{"CallReason", func(r *CallReq) { r.CallReason = "Your appointment" },
+			"CallReason", []string{"Your appointment"}},
+	} {
+		req := CallReq{From: FromPhoneNumber, To: ToPhoneNumber, URL: "https://example.com/call.twiml"}
+		test.conf(&req)
+		if _, err := TestClient.SubmitCall(req); err != nil {
+			t.Fatalf("%s: %s", test.name, err.Error())
+		}
+		form := srv.AssertRequested(t, "POST", "/Calls.json").Form
+		if got := form[test.param]; strings.Join(got, " ") != strings.Join(test.want, " ") {
+			t.Fatalf("%s: expected %s=%q, got %q", test.name, test.param, test.want, got)
+		}
+	}
+}
+
+func TestCallReqValidate(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		conf func(*CallReq)
+	}{
+		{"no instructions", func(r *CallReq) { r.URL = "" }},
+		{"URL and Twiml", func(r *CallReq) { r.Twiml = "<Response><Say>Hi</Say></Response>" }},
+		{"URL and ApplicationSID", func(r *CallReq) { r.ApplicationSID = "AP123" }},
+		{"AsyncAMD without MachineDetection", func(r *CallReq) { r.AsyncAMD = true }},
+		{"IfMachine and MachineDetection", func(r *CallReq) {
+			r.IfMachine, r.MachineDetection = "Continue", "Enable"
+		}},
+	} {
+		req := CallReq{From: FromPhoneNumber, To: ToPhoneNumber, URL: "https://example.com/call.twiml"}
+		test.conf(&req)
+		if err := req.Validate(); err == nil {
+			t.Fatalf("%s: request passed validation", test.name)
+		}
+	}
+}
+
+func TestUpdateCall(t *testing.T) {
+	srv := requireFake(t)
+	sid := srv.Add("Calls", utwiltest.Resource{"status": "in-progress"})["sid"].(string)
+
+	call, err := TestClient.RedirectCall(sid, "https://example.com/next.twiml")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	req := srv.AssertRequested(t, "POST", "/Calls/"+sid+".json")
+	if req.Form.Get("Url") != "https://example.com/next.twiml" || len(req.Form) != 1 {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+
+	call, err = TestClient.UpdateCall(sid, CallUpdate{Twiml: "<Response><Hangup/></Response>"})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if req := srv.AssertRequested(t, "POST", "/Calls/"+sid+".json"); req.Form.Get("Twiml") == "" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+
+	call, err = TestClient.HangupCall(sid)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if CallStatus(call.Status) != CallCompleted {
+		t.Fatalf("unexpected call: %+v", call)
+	}
+
+	_, err = TestClient.CancelCall("CA00000000000000000000000000000000")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+
+	srv.ResetRequests()
+	_, err = TestClient.UpdateCall(sid, CallUpdate{
+		URL:   "https://example.com/next.twiml",
+		Twiml: "<Response><Hangup/></Response>",
+	})
+	if err == nil {
+		t.Fatalf("update with both URL and Twiml passed validation")
+	}
+	srv.AssertRequestCount(t, "POST", "/Calls/"+sid+".json", 0)
+}
@@ -1,8 +1,10 @@
 package utwil
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -21,61 +23,172 @@ type Client struct {
 	AccountSID string
 	AuthToken  string
 	HTTPClient *http.Client
+
+	// BaseURL and LookupURL override the package-level hosts of the same
+	// name when non-empty, e.g. to target a regional edge, a proxy, or a
+	// local stand-in during tests. Next-page URIs follow BaseURL as well.
+	BaseURL   string
+	LookupURL string
+
+	// RetryPolicy decides whether failed requests are retried; nil disables
+	// retries.
+	RetryPolicy RetryPolicy
+
+	// RateLimiter, if set, throttles outbound calls and messages
+	RateLimiter *RateLimiter
+
+	// SubaccountSID, if set, is the account the client operates on, while
+	// requests are still authenticated with AccountSID and AuthToken. See
+	// Client.Subaccount.
+	SubaccountSID string
 }
 
 // NewClient exists as a stable interface to create a new utwil.Client.
 func NewClient(accountSID, authToken string) Client {
-	return Client{accountSID, authToken, &http.Client{}}
+	return Client{
+		AccountSID:  accountSID,
+		AuthToken:   authToken,
+		HTTPClient:  &http.Client{},
+		RetryPolicy: DefaultRetryPolicy(),
+	}
 }
 
-func (c *Client) getJSON(url string, result interface{}) error {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("GetJSON(): %s", err)
-	}
-	req.SetBasicAuth(c.AccountSID, c.AuthToken)
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("GetJSON(): %s", err)
+// restURL returns the REST API host the client is configured to use
+func (c *Client) restURL() string {
+	if c.BaseURL != "" {
+		return strings.TrimSuffix(c.BaseURL, "/")
 	}
+	return BaseURL
+}
 
-	if resp.StatusCode != 200 {
-		re := RESTException{}
-		json.NewDecoder(resp.Body).Decode(&re)
-		return re
+// lookupURL returns the Lookups API host the client is configured to use
+func (c *Client) lookupURL() string {
+	if c.LookupURL != "" {
+		return strings.TrimSuffix(c.LookupURL, "/")
 	}
-	return json.NewDecoder(resp.Body).Decode(&result)
+	return LookupURL
 }
 
-func (c *Client) postForm(url string, values url.Values, result interface{}) error {
-	req, err := http.NewRequest("POST", url, strings.NewReader(values.Encode()))
-	if err != nil {
-		return fmt.Errorf("PostForm(): %s", err)
+func (c *Client) getJSON(ctx context.Context, url string, result interface{}) error {
+	err := c.doJSON(ctx, "GET", url, nil, result)
+	if _, ok := err.(RESTException); err != nil && !ok {
+		return fmt.Errorf("GetJSON(): %w", err)
 	}
-	req.SetBasicAuth(c.AccountSID, c.AuthToken)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	resp, err := c.HTTPClient.Do(req)
+	return err
+}
+
+func (c *Client) postForm(ctx context.Context, url string, values url.Values, result interface{}) error {
+	return c.doJSON(ctx, "POST", url, values, result)
+}
+
+// delete removes the resource at url, which Twilio answers with 204 No Content
+func (c *Client) delete(ctx context.Context, url string) error {
+	resp, err := c.do(ctx, "DELETE", url, nil)
 	if err != nil {
 		return err
 	}
+	return resp.Body.Close()
+}
 
-	// HTTP 2xx codes are successful, others are errors
-	if resp.StatusCode >= 300 || resp.StatusCode < 200 {
-		err := RESTException{}
-		json.NewDecoder(resp.Body).Decode(&err)
+// doJSON sends the request and decodes a successful response into result
+func (c *Client) doJSON(ctx context.Context, method, url string, values url.Values, result interface{}) error {
+	resp, err := c.do(ctx, method, url, values)
+	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	return json.NewDecoder(resp.Body).Decode(&result)
 }
 
+// do sends an authenticated request, retrying it as the client's RetryPolicy
+// allows. values, if any, are sent form-encoded. Responses outside of HTTP 2xx
+// are returned as a RESTException; otherwise the caller must close the body.
+func (c *Client) do(ctx context.Context, method, url string, values url.Values) (*http.Response, error) {
+	var body string
+	if values != nil {
+		body = values.Encode()
+	}
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(c.AccountSID, c.AuthToken)
+		if values != nil {
+			req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		}
+		if key := idempotencyKeyFrom(ctx); key != "" {
+			req.Header.Set(IdempotencyTokenHeader, key)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		// HTTP 2xx codes are successful, others are errors
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return resp, nil
+		}
+		if c.RetryPolicy != nil {
+			if wait, ok := c.RetryPolicy.Retry(attempt, req, resp, err); ok {
+				if resp != nil {
+					io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+				if err := sleep(ctx, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		re := RESTException{}
+		json.NewDecoder(resp.Body).Decode(&re)
+		if re.Status == nil {
+			re.Status = resp.StatusCode
+		}
+		return nil, re
+	}
+}
+
+// Subaccount returns a copy of the client that operates on the subaccount
+// with the given SID, authenticating with the credentials of c:
+//
+//	tenant := client.Subaccount("AC...")
+//	msg, err := tenant.SendSMS("+15551231234", "+15553214321", "Hello, world!")
+//
+// Twilio signs the webhooks of a subaccount with the subaccount's own auth
+// token, Account.AuthToken, so validate them with a Validator made from it.
+func (c *Client) Subaccount(sid string) Client {
+	sub := *c
+	sub.SubaccountSID = sid
+	return sub
+}
+
+// accountSID returns the SID of the account the client operates on
+func (c *Client) accountSID() string {
+	if c.SubaccountSID != "" {
+		return c.SubaccountSID
+	}
+	return c.AccountSID
+}
+
 func (c *Client) urlPrefix() string {
-	return fmt.Sprintf("%s/%s/Accounts/%s", BaseURL, APIVersion, c.AccountSID)
+	return fmt.Sprintf("%s/%s/Accounts/%s", c.restURL(), APIVersion, c.accountSID())
 }
 
 func (c *Client) callsURL() string {
 	return fmt.Sprintf("%s/Calls.json", c.urlPrefix())
 }
 
+func (c *Client) callURL(sid string) string {
+	return fmt.Sprintf("%s/Calls/%s.json", c.urlPrefix(), sid)
+}
+
 func (c *Client) messagesURL() string {
 	return fmt.Sprintf("%s/Messages.json", c.urlPrefix())
 }
+
+func (c *Client) messageURL(sid string) string {
+	return fmt.Sprintf("%s/Messages/%s.json", c.urlPrefix(), sid)
+}
@@ -0,0 +1,136 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Requests, including next-page URIs, go to the client's configured hosts
+func TestClientBaseURL(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v1/PhoneNumbers/"):
+			fmt.Fprint(w, `{"phone_number": "+15551231234"}`)
+		case r.URL.Query().Get("Page") == "1":
+			fmt.Fprint(w, `{"calls": [{"sid": "CA2"}], "next_page_uri": null}`)
+		default:
+			fmt.Fprintf(w, `{"calls": [{"sid": "CA1"}], "next_page_uri": "%s?Page=1"}`, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("AC123", "token")
+	client.BaseURL = srv.URL
+	client.LookupURL = srv.URL + "/v1/"
+
+	iter := client.Calls().Iter()
+	var call Call
+	var sids []string
+	for iter.Next(&call) {
+		sids = append(sids, call.SID)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if strings.Join(sids, ",") != "CA1,CA2" {
+		t.Fatalf("unexpected calls: %v", sids)
+	}
+
+	lookup, err := client.LookupNoCarrier("+15551231234")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if lookup.PhoneNumber != "+15551231234" {
+		t.Fatalf("unexpected lookup: %+v", lookup)
+	}
+
+	expected := []string{
+		"/2010-04-01/Accounts/AC123/Calls.json",
+		"/2010-04-01/Accounts/AC123/Calls.json",
+		"/v1/PhoneNumbers/+15551231234",
+	}
+	if strings.Join(paths, " ") != strings.Join(expected, " ") {
+		t.Fatalf("unexpected paths: %v", paths)
+	}
+}
+
+// 503s are retried for GETs, and for POSTs carrying an idempotency key only
+// when RetryKeyedPOSTs is set
+func TestClientRetry(t *testing.T) {
+	var attempts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, r.Method+" "+r.Header.Get(IdempotencyTokenHeader))
+		if len(attempts)%2 == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"code": 20503, "message": "Service Unavailable", "status": 503}`)
+			return
+		}
+		fmt.Fprint(w, `{"sid": "SM123", "calls": []}`)
+	}))
+	defer srv.Close()
+
+	client := NewClient("AC123", "token")
+	client.BaseURL = srv.URL
+	client.RetryPolicy = &Backoff{MaxRetries: 2, MinDelay: time.Millisecond}
+
+	iter := client.Calls().Iter()
+	var call Call
+	for iter.Next(&call) {
+	}
+	if iter.Err() != nil {
+		t.Fatalf("GET was not retried: %s", iter.Err())
+	}
+
+	attempts = nil
+	_, err := client.SendSMS(FromPhoneNumber, ToPhoneNumber, "Hello, world!")
+	if err == nil || len(attempts) != 1 {
+		t.Fatalf("POST without idempotency key was retried: %v", attempts)
+	}
+
+	attempts = nil
+	ctx := WithIdempotencyKey(context.Background(), "key-1")
+	_, err = client.SendSMSContext(ctx, FromPhoneNumber, ToPhoneNumber, "Hello, world!")
+	if err == nil || len(attempts) != 1 {
+		t.Fatalf("POST with idempotency key was retried without RetryKeyedPOSTs: %v", attempts)
+	}
+
+	attempts = nil
+	client.RetryPolicy = &Backoff{MaxRetries: 2, MinDelay: time.Millisecond, RetryKeyedPOSTs: true}
+	msg, err := client.SendSMSContext(ctx, FromPhoneNumber, ToPhoneNumber, "Hello, world!")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if msg.SID != "SM123" || len(attempts) != 2 || attempts[1] != "POST key-1" {
+		t.Fatalf("POST with idempotency key was not retried: %v", attempts)
+	}
+}
+
+// Retry-After is honoured, but never beyond MaxDelay
+func TestBackoffRetryAfter(t *testing.T) {
+	b := &Backoff{MaxRetries: 1, MinDelay: time.Millisecond, MaxDelay: time.Minute}
+	req := httptest.NewRequest("GET", "https://api.twilio.com/", nil)
+	for _, test := range []struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"30", 30 * time.Second},
+		{"86400", time.Minute},
+		{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), time.Minute},
+	} {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		resp.Header.Set("Retry-After", test.retryAfter)
+		wait, ok := b.Retry(1, req, resp, nil)
+		if !ok || wait != test.expected {
+			t.Fatalf("Retry-After %s: waited %s, expected %s", test.retryAfter, wait, test.expected)
+		}
+	}
+}
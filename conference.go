@@ -0,0 +1,538 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Conference is the Go-representation of Twilio REST API's conference, as
+// started by the <Conference> noun of TwiML's <Dial>.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/conference-resource
+type Conference struct {
+	AccountSID              string `json:"account_sid"`
+	APIVersion              string `json:"api_version"`
+	CallSIDEndingConference string `json:"call_sid_ending_conference"`
+	DateCreated             *Time  `json:"date_created"`
+	DateUpdated             *Time  `json:"date_updated"`
+	FriendlyName            string `json:"friendly_name"`
+	ReasonConferenceEnded   string `json:"reason_conference_ended"`
+	Region                  string `json:"region"`
+	SID                     string `json:"sid"`
+	Status                  string `json:"status"`
+	SubresourceURIs         struct {
+		Participants string `json:"participants"`
+		Recordings   string `json:"recordings"`
+	} `json:"subresource_uris"`
+	URI string `json:"uri"`
+}
+
+// ConferenceStatus is the status of a conference
+type ConferenceStatus string
+
+// Conference statuses. Only ConferenceCompleted can be set, to end a
+// conference.
+const (
+	ConferenceInit       ConferenceStatus = "init"
+	ConferenceInProgress ConferenceStatus = "in-progress"
+	ConferenceCompleted  ConferenceStatus = "completed"
+)
+
+// Participant is the Go-representation of Twilio REST API's conference
+// participant. Participants are identified by the SID of their call.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/conference-participant-resource
+type Participant struct {
+	AccountSID             string `json:"account_sid"`
+	CallSID                string `json:"call_sid"`
+	CallSIDToCoach         string `json:"call_sid_to_coach"`
+	Coaching               bool   `json:"coaching"`
+	ConferenceSID          string `json:"conference_sid"`
+	DateCreated            *Time  `json:"date_created"`
+	DateUpdated            *Time  `json:"date_updated"`
+	EndConferenceOnExit    bool   `json:"end_conference_on_exit"`
+	Hold                   bool   `json:"hold"`
+	Label                  string `json:"label"`
+	Muted                  bool   `json:"muted"`
+	StartConferenceOnEnter bool   `json:"start_conference_on_enter"`
+	Status                 string `json:"status"`
+	URI                    string `json:"uri"`
+}
+
+func (c *Client) conferencesURL() string {
+	return fmt.Sprintf("%s/Conferences.json", c.urlPrefix())
+}
+
+func (c *Client) conferenceURL(sid string) string {
+	return fmt.Sprintf("%s/Conferences/%s.json", c.urlPrefix(), url.PathEscape(sid))
+}
+
+func (c *Client) participantsURL(confSID string) string {
+	return fmt.Sprintf("%s/Conferences/%s/Participants.json", c.urlPrefix(), url.PathEscape(confSID))
+}
+
+func (c *Client) participantURL(confSID, callSID string) string {
+	return fmt.Sprintf("%s/Conferences/%s/Participants/%s.json", c.urlPrefix(),
+		url.PathEscape(confSID), url.PathEscape(callSID))
+}
+
+// ConferenceListQuery is a struct that contains an embedded utwil.ListQuery.
+// The typing allows the correctly-typed iterator/list to be returned.
+type ConferenceListQuery struct{ *ListQuery }
+
+// Conferences takes a vargs of utwil.ListQueryConf functions to configure
+// the query to be sent to the Twilio API:
+//
+//	iter := client.Conferences(
+//		utwil.Status("in-progress"),
+//		utwil.FriendlyName("Room 1234")).Iter()
+func (c *Client) Conferences(confs ...ListQueryConf) *ConferenceListQuery {
+	return &ConferenceListQuery{ListQuery: newListQuery(c, confs...)}
+}
+
+// Iter creates an iterator that iterates utwil.Conference results
+func (q *ConferenceListQuery) Iter() *ConferenceIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as ConferenceListQuery.Iter, but every page is
+// fetched with ctx and iteration stops once ctx is done.
+func (q *ConferenceListQuery) IterContext(ctx context.Context) *ConferenceIter {
+	initURI := fmt.Sprintf("%s?%s", q.conferencesURL(), q.Values.Encode())
+	iter := &ConferenceIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &conferenceList{}
+	return iter
+}
+
+type conferenceList struct {
+	Conferences []Conference `json:"conferences"`
+	listResource
+}
+
+func (cl conferenceList) item(idx int) interface{} { return cl.Conferences[idx] }
+func (cl conferenceList) size() int                { return len(cl.Conferences) }
+func (cl conferenceList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return cl.loadNextPage(ctx, c, &conferenceList{})
+}
+
+// GetConference fetches the conference with the given SID. A *NotFoundError
+// is returned if there is no such conference.
+func (c *Client) GetConference(sid string) (*Conference, error) {
+	return c.GetConferenceContext(context.Background(), sid)
+}
+
+// GetConferenceContext is the same as Client.GetConference, but the request
+// is bound to ctx.
+func (c *Client) GetConferenceContext(ctx context.Context, sid string) (*Conference, error) {
+	conf := &Conference{}
+	if err := c.getJSON(ctx, c.conferenceURL(sid), conf); err != nil {
+		return nil, notFound(err, "Conference", sid)
+	}
+	return conf, nil
+}
+
+// ConferenceUpdate is the Go-representation of the Twilio REST API's
+// request to modify a conference in progress.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/conference-resource#update-a-conference-resource
+type ConferenceUpdate struct {
+	Status         ConferenceStatus
+	AnnounceURL    string
+	AnnounceMethod string
+}
+
+// UpdateConference modifies the conference with the given SID, populating
+// form fields only if they contain a non-zero value, and returns the updated
+// conference.
+func (c *Client) UpdateConference(sid string, update ConferenceUpdate) (*Conference, error) {
+	return c.UpdateConferenceContext(context.Background(), sid, update)
+}
+
+// UpdateConferenceContext is the same as Client.UpdateConference, but the
+// request is bound to ctx.
+func (c *Client) UpdateConferenceContext(ctx context.Context, sid string, update ConferenceUpdate) (*Conference, error) {
+	values := url.Values{}
+	if update.Status != "" {
+		values.Set("Status", string(update.Status))
+	}
+	if update.AnnounceURL != "" {
+		values.Set("AnnounceUrl", update.AnnounceURL)
+	}
+	if update.AnnounceMethod != "" {
+		values.Set("AnnounceMethod", update.AnnounceMethod)
+	}
+	conf := &Conference{}
+	if err := c.postForm(ctx, c.conferenceURL(sid), values, conf); err != nil {
+		return nil, notFound(err, "Conference", sid)
+	}
+	return conf, nil
+}
+
+// EndConference ends the conference, disconnecting all participants.
+func (c *Client) EndConference(sid string) (*Conference, error) {
+	return c.EndConferenceContext(context.Background(), sid)
+}
+
+// EndConferenceContext is the same as Client.EndConference, but the request
+// is bound to ctx.
+func (c *Client) EndConferenceContext(ctx context.Context, sid string) (*Conference, error) {
+	return c.UpdateConferenceContext(ctx, sid, ConferenceUpdate{Status: ConferenceCompleted})
+}
+
+// AnnounceConference plays the TwiML at announceURL, which may only contain
+// <Play> and <Say>, to every participant of the conference.
+func (c *Client) AnnounceConference(sid, announceURL string) (*Conference, error) {
+	return c.AnnounceConferenceContext(context.Background(), sid, announceURL)
+}
+
+// AnnounceConferenceContext is the same as Client.AnnounceConference, but the
+// request is bound to ctx.
+func (c *Client) AnnounceConferenceContext(ctx context.Context, sid, announceURL string) (*Conference, error) {
+	return c.UpdateConferenceContext(ctx, sid, ConferenceUpdate{AnnounceURL: announceURL})
+}
+
+// ParticipantListQuery is a struct that contains an embedded
+// utwil.ListQuery. The typing allows the correctly-typed iterator/list to be
+// returned.
+type ParticipantListQuery struct {
+	*ListQuery
+	conferenceSID string
+}
+
+// Participants takes the SID of a conference and a vargs of
+// utwil.ListQueryConf functions to configure a query for its participants:
+//
+//	iter := client.Participants(conf.SID).Iter()
+//	var p utwil.Participant
+//	for iter.Next(&p) {
+//		// use p
+//	}
+func (c *Client) Participants(confSID string, confs ...ListQueryConf) *ParticipantListQuery {
+	return &ParticipantListQuery{ListQuery: newListQuery(c, confs...), conferenceSID: confSID}
+}
+
+// Iter creates an iterator that iterates utwil.Participant results
+func (q *ParticipantListQuery) Iter() *ParticipantIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as ParticipantListQuery.Iter, but every page is
+// fetched with ctx and iteration stops once ctx is done.
+func (q *ParticipantListQuery) IterContext(ctx context.Context) *ParticipantIter {
+	initURI := fmt.Sprintf("%s?%s", q.participantsURL(q.conferenceSID), q.Values.Encode())
+	iter := &ParticipantIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &participantList{}
+	return iter
+}
+
+type participantList struct {
+	Participants []Participant `json:"participants"`
+	listResource
+}
+
+func (pl participantList) item(idx int) interface{} { return pl.Participants[idx] }
+func (pl participantList) size() int                { return len(pl.Participants) }
+func (pl participantList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return pl.loadNextPage(ctx, c, &participantList{})
+}
+
+// GetParticipant fetches the participant of a conference with the given call
+// SID. A *NotFoundError is returned if there is no such participant.
+func (c *Client) GetParticipant(confSID, callSID string) (*Participant, error) {
+	return c.GetParticipantContext(context.Background(), confSID, callSID)
+}
+
+// GetParticipantContext is the same as Client.GetParticipant, but the request
+// is bound to ctx.
+func (c *Client) GetParticipantContext(ctx context.Context, confSID, callSID string) (*Participant, error) {
+	p := &Participant{}
+	if err := c.getJSON(ctx, c.participantURL(confSID, callSID), p); err != nil {
+		return nil, notFound(err, "Participant", callSID)
+	}
+	return p, nil
+}
+
+// ParticipantReq is the Go-representation of the Twilio REST API's request
+// to dial a phone number or client into a conference.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/conference-participant-resource#create-a-participant
+type ParticipantReq struct {
+	From                           string
+	To                             string
+	Label                          string
+	CallerID                       string
+	StatusCallback                 string
+	StatusCallbackMethod           string
+	StatusCallbackEvent            []string
+	Timeout                        int
+	TimeLimit                      int
+	Record                         bool
+	Muted                          bool
+	Beep                           string
+	StartConferenceOnEnter         *bool
+	EndConferenceOnExit            bool
+	WaitURL                        string
+	WaitMethod                     string
+	EarlyMedia                     bool
+	MaxParticipants                int
+	ConferenceRecord               string
+	ConferenceStatusCallback       string
+	ConferenceStatusCallbackMethod string
+	ConferenceStatusCallbackEvent  []string
+	Coaching                       bool
+	CallSIDToCoach                 string
+}
+
+// AddParticipant dials req.To into the conference, populating form fields
+// only if they contain a non-zero value. confSID may also be the friendly
+// name of a conference that has yet to start.
+//
+// Example:
+//
+//	p, err := client.AddParticipant(conf.SID, utwil.ParticipantReq{
+//		From:                "+15551231234",
+//		To:                  "+15553214321",
+//		EndConferenceOnExit: true,
+//	})
+func (c *Client) AddParticipant(confSID string, req ParticipantReq) (*Participant, error) {
+	return c.AddParticipantContext(context.Background(), confSID, req)
+}
+
+// AddParticipantContext is the same as Client.AddParticipant, but the request
+// is bound to ctx.
+func (c *Client) AddParticipantContext(ctx context.Context, confSID string, req ParticipantReq) (*Participant, error) {
+	values := url.Values{}
+	values.Set("From", req.From)
+	values.Set("To", req.To)
+	if req.Label != "" {
+		values.Set("Label", req.Label)
+	}
+	if req.CallerID != "" {
+		values.Set("CallerId", req.CallerID)
+	}
+	if req.StatusCallback != "" {
+		values.Set("StatusCallback", req.StatusCallback)
+	}
+	if req.StatusCallbackMethod != "" {
+		values.Set("StatusCallbackMethod", req.StatusCallbackMethod)
+	}
+	for _, event := range req.StatusCallbackEvent {
+		values.Add("StatusCallbackEvent", event)
+	}
+	if req.Timeout > 0 {
+		values.Set("Timeout", strconv.Itoa(req.Timeout))
+	}
+	if req.TimeLimit > 0 {
+		values.Set("TimeLimit", strconv.Itoa(req.TimeLimit))
+	}
+	if req.Record {
+		values.Set("Record", "true")
+	}
+	if req.Muted {
+		values.Set("Muted", "true")
+	}
+	if req.Beep != "" {
+		values.Set("Beep", req.Beep)
+	}
+	if req.StartConferenceOnEnter != nil {
+		values.Set("StartConferenceOnEnter", strconv.FormatBool(*req.StartConferenceOnEnter))
+	}
+	if req.EndConferenceOnExit {
+		values.Set("EndConferenceOnExit", "true")
+	}
+	if req.WaitURL != "" {
+		values.Set("WaitUrl", req.WaitURL)
+	}
+	if req.WaitMethod != "" {
+		values.Set("WaitMethod", req.WaitMethod)
+	}
+	if req.EarlyMedia {
+		values.Set("EarlyMedia", "true")
+	}
+	if req.MaxParticipants > 0 {
+		values.Set("MaxParticipants", strconv.Itoa(req.MaxParticipants))
+	}
+	if req.ConferenceRecord != "" {
+		values.Set("ConferenceRecord", req.ConferenceRecord)
+	}
+	if req.ConferenceStatusCallback != "" {
+		values.Set("ConferenceStatusCallback", req.ConferenceStatusCallback)
+	}
+	if req.ConferenceStatusCallbackMethod != "" {
+		values.Set("ConferenceStatusCallbackMethod", req.ConferenceStatusCallbackMethod)
+	}
+	for _, event := range req.ConferenceStatusCallbackEvent {
+		values.Add("ConferenceStatusCallbackEvent", event)
+	}
+	if req.Coaching {
+		values.Set("Coaching", "true")
+	}
+	if req.CallSIDToCoach != "" {
+		values.Set("CallSidToCoach", req.CallSIDToCoach)
+	}
+	if err := c.throttle(ctx, req.From); err != nil {
+		return nil, err
+	}
+	p := &Participant{}
+	if err := c.postForm(ctx, c.participantsURL(confSID), values, p); err != nil {
+		return nil, notFound(err, "Conference", confSID)
+	}
+	return p, nil
+}
+
+// ParticipantUpdate is the Go-representation of the Twilio REST API's
+// request to modify a participant. Nil flags are left unchanged.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/conference-participant-resource#update-a-participant-resource
+type ParticipantUpdate struct {
+	Muted               *bool
+	Hold                *bool
+	HoldURL             string
+	HoldMethod          string
+	AnnounceURL         string
+	AnnounceMethod      string
+	WaitURL             string
+	WaitMethod          string
+	BeepOnExit          *bool
+	EndConferenceOnExit *bool
+	Coaching            *bool
+	CallSIDToCoach      string
+}
+
+// UpdateParticipant modifies the participant of a conference with the given
+// call SID, populating form fields only if they are set, and returns the
+// updated participant.
+func (c *Client) UpdateParticipant(confSID, callSID string, update ParticipantUpdate) (*Participant, error) {
+	return c.UpdateParticipantContext(context.Background(), confSID, callSID, update)
+}
+
+// UpdateParticipantContext is the same as Client.UpdateParticipant, but the
+// request is bound to ctx.
+func (c *Client) UpdateParticipantContext(ctx context.Context, confSID, callSID string, update ParticipantUpdate) (*Participant, error) {
+	values := url.Values{}
+	if update.Muted != nil {
+		values.Set("Muted", strconv.FormatBool(*update.Muted))
+	}
+	if update.Hold != nil {
+		values.Set("Hold", strconv.FormatBool(*update.Hold))
+	}
+	if update.HoldURL != "" {
+		values.Set("HoldUrl", update.HoldURL)
+	}
+	if update.HoldMethod != "" {
+		values.Set("HoldMethod", update.HoldMethod)
+	}
+	if update.AnnounceURL != "" {
+		values.Set("AnnounceUrl", update.AnnounceURL)
+	}
+	if update.AnnounceMethod != "" {
+		values.Set("AnnounceMethod", update.AnnounceMethod)
+	}
+	if update.WaitURL != "" {
+		values.Set("WaitUrl", update.WaitURL)
+	}
+	if update.WaitMethod != "" {
+		values.Set("WaitMethod", update.WaitMethod)
+	}
+	if update.BeepOnExit != nil {
+		values.Set("BeepOnExit", strconv.FormatBool(*update.BeepOnExit))
+	}
+	if update.EndConferenceOnExit != nil {
+		values.Set("EndConferenceOnExit", strconv.FormatBool(*update.EndConferenceOnExit))
+	}
+	if update.Coaching != nil {
+		values.Set("Coaching", strconv.FormatBool(*update.Coaching))
+	}
+	if update.CallSIDToCoach != "" {
+		values.Set("CallSidToCoach", update.CallSIDToCoach)
+	}
+	p := &Participant{}
+	if err := c.postForm(ctx, c.participantURL(confSID, callSID), values, p); err != nil {
+		return nil, notFound(err, "Participant", callSID)
+	}
+	return p, nil
+}
+
+// MuteParticipant mutes the participant, who can still hear the conference.
+func (c *Client) MuteParticipant(confSID, callSID string) (*Participant, error) {
+	return c.MuteParticipantContext(context.Background(), confSID, callSID)
+}
+
+// MuteParticipantContext is the same as Client.MuteParticipant, but the
+// request is bound to ctx.
+func (c *Client) MuteParticipantContext(ctx context.Context, confSID, callSID string) (*Participant, error) {
+	return c.UpdateParticipantContext(ctx, confSID, callSID, ParticipantUpdate{Muted: Bool(true)})
+}
+
+// UnmuteParticipant unmutes the participant.
+func (c *Client) UnmuteParticipant(confSID, callSID string) (*Participant, error) {
+	return c.UnmuteParticipantContext(context.Background(), confSID, callSID)
+}
+
+// UnmuteParticipantContext is the same as Client.UnmuteParticipant, but the
+// request is bound to ctx.
+func (c *Client) UnmuteParticipantContext(ctx context.Context, confSID, callSID string) (*Participant, error) {
+	return c.UpdateParticipantContext(ctx, confSID, callSID, ParticipantUpdate{Muted: Bool(false)})
+}
+
+// HoldParticipant puts the participant on hold, playing the TwiML or audio
+// file at holdURL to them meanwhile; Twilio plays its default hold music if
+// holdURL is empty.
+func (c *Client) HoldParticipant(confSID, callSID, holdURL string) (*Participant, error) {
+	return c.HoldParticipantContext(context.Background(), confSID, callSID, holdURL)
+}
+
+// HoldParticipantContext is the same as Client.HoldParticipant, but the
+// request is bound to ctx.
+func (c *Client) HoldParticipantContext(ctx context.Context, confSID, callSID, holdURL string) (*Participant, error) {
+	return c.UpdateParticipantContext(ctx, confSID, callSID, ParticipantUpdate{Hold: Bool(true), HoldURL: holdURL})
+}
+
+// UnholdParticipant takes the participant off hold, back into the
+// conference.
+func (c *Client) UnholdParticipant(confSID, callSID string) (*Participant, error) {
+	return c.UnholdParticipantContext(context.Background(), confSID, callSID)
+}
+
+// UnholdParticipantContext is the same as Client.UnholdParticipant, but the
+// request is bound to ctx.
+func (c *Client) UnholdParticipantContext(ctx context.Context, confSID, callSID string) (*Participant, error) {
+	return c.UpdateParticipantContext(ctx, confSID, callSID, ParticipantUpdate{Hold: Bool(false)})
+}
+
+// AnnounceToParticipant plays the TwiML at announceURL, which may only
+// contain <Play> and <Say>, to the participant alone.
+func (c *Client) AnnounceToParticipant(confSID, callSID, announceURL string) (*Participant, error) {
+	return c.AnnounceToParticipantContext(context.Background(), confSID, callSID, announceURL)
+}
+
+// AnnounceToParticipantContext is the same as Client.AnnounceToParticipant,
+// but the request is bound to ctx.
+func (c *Client) AnnounceToParticipantContext(ctx context.Context, confSID, callSID, announceURL string) (*Participant, error) {
+	return c.UpdateParticipantContext(ctx, confSID, callSID, ParticipantUpdate{AnnounceURL: announceURL})
+}
+
+// KickParticipant removes the participant from the conference, hanging up
+// their call. A *NotFoundError is returned if there is no such participant.
+func (c *Client) KickParticipant(confSID, callSID string) error {
+	return c.KickParticipantContext(context.Background(), confSID, callSID)
+}
+
+// KickParticipantContext is the same as Client.KickParticipant, but the
+// request is bound to ctx.
+func (c *Client) KickParticipantContext(ctx context.Context, confSID, callSID string) error {
+	err := c.delete(ctx, c.participantURL(confSID, callSID))
+	return notFound(err, "Participant", callSID)
+}
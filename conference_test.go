@@ -0,0 +1,127 @@
+package utwil
+
+import (
+	"testing"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestConferences(t *testing.T) {
+	srv := requireFake(t)
+	srv.Add("Conferences", utwiltest.Resource{"friendly_name": "Room 1234", "status": "completed"})
+	live := srv.Add("Conferences", utwiltest.Resource{"friendly_name": "Room 1234", "status": "in-progress"})
+	srv.Add("Conferences", utwiltest.Resource{"friendly_name": "Room 5678", "status": "in-progress"})
+
+	iter := TestClient.Conferences(Status(string(ConferenceInProgress)), FriendlyName("Room 1234")).Iter()
+	var conf Conference
+	var all []Conference
+	for iter.Next(&conf) {
+		all = append(all, conf)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if len(all) != 1 || all[0].SID != live["sid"] {
+		t.Fatalf("unexpected conferences: %+v", all)
+	}
+
+	if _, err := TestClient.AnnounceConference(conf.SID, "https://example.com/closing.twiml"); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	ended, err := TestClient.EndConference(conf.SID)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if ConferenceStatus(ended.Status) != ConferenceCompleted {
+		t.Fatalf("unexpected conference: %+v", ended)
+	}
+	if _, err := TestClient.GetConference("CF00000000000000000000000000000000"); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
+
+func TestParticipants(t *testing.T) {
+	srv := requireFake(t)
+	confSID := srv.Add("Conferences", utwiltest.Resource{"status": "in-progress"})["sid"].(string)
+
+	p, err := TestClient.AddParticipant(confSID, ParticipantReq{
+		From:                   FromPhoneNumber,
+		To:                     ToPhoneNumber,
+		Label:                  "customer",
+		StartConferenceOnEnter: Bool(false),
+		StatusCallbackEvent:    []string{"join", "leave"},
+	})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if p.ConferenceSID != confSID || p.Label != "customer" || p.StartConferenceOnEnter || p.CallSID == "" {
+		t.Fatalf("unexpected participant: %+v", p)
+	}
+	form := srv.AssertRequested(t, "POST", "/Participants.json").Form
+	if form.Get("StartConferenceOnEnter") != "false" || len(form["StatusCallbackEvent"]) != 2 {
+		t.Fatalf("unexpected form: %v", form)
+	}
+	if _, ok := srv.Get("Calls", p.CallSID); !ok {
+		t.Fatalf("participant's call was not created")
+	}
+
+	if p, err = TestClient.MuteParticipant(confSID, p.CallSID); err != nil || !p.Muted {
+		t.Fatalf("unexpected participant: %+v, %v", p, err)
+	}
+	if p, err = TestClient.HoldParticipant(confSID, p.CallSID, "https://example.com/hold.mp3"); err != nil || !p.Hold {
+		t.Fatalf("unexpected participant: %+v, %v", p, err)
+	}
+	req := srv.AssertRequested(t, "POST", "/Participants/"+p.CallSID+".json")
+	if req.Form.Encode() != "Hold=true&HoldUrl=https%3A%2F%2Fexample.com%2Fhold.mp3" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+	if p, err = TestClient.UnmuteParticipant(confSID, p.CallSID); err != nil || p.Muted || !p.Hold {
+		t.Fatalf("unexpected participant: %+v, %v", p, err)
+	}
+	if _, err = TestClient.AnnounceToParticipant(confSID, p.CallSID, "https://example.com/hi.twiml"); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+
+	iter := TestClient.Participants(confSID).Iter()
+	var listed Participant
+	if !iter.Next(&listed) || listed.CallSID != p.CallSID || iter.Next(&listed) {
+		t.Fatalf("unexpected participants: %v", iter.Err())
+	}
+
+	if err := TestClient.KickParticipant(confSID, p.CallSID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, err := TestClient.GetParticipant(confSID, p.CallSID); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
+
+func TestParticipantsByFriendlyName(t *testing.T) {
+	srv := requireFake(t)
+	name := "Room #1/2? 100%"
+	confSID := srv.Add("Conferences", utwiltest.Resource{"friendly_name": name, "status": "in-progress"})["sid"].(string)
+
+	p, err := TestClient.AddParticipant(name, ParticipantReq{From: FromPhoneNumber, To: ToPhoneNumber})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if p.ConferenceSID != confSID {
+		t.Fatalf("unexpected participant: %+v", p)
+	}
+	req := srv.AssertRequested(t, "POST", "/Participants.json")
+	if req.Path != "/"+APIVersion+"/Accounts/"+TestClient.AccountSID+"/Conferences/"+name+"/Participants.json" {
+		t.Fatalf("unexpected path: %s", req.Path)
+	}
+
+	p, err = TestClient.AddParticipant("Room 9/9", ParticipantReq{From: FromPhoneNumber, To: ToPhoneNumber})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	started, err := TestClient.GetConference(p.ConferenceSID)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if started.FriendlyName != "Room 9/9" {
+		t.Fatalf("unexpected conference: %+v", started)
+	}
+}
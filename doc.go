@@ -7,8 +7,9 @@
 //
 // These actions will incur the appropriate costs on your Twilio account.
 //
-// Before go test, populate env vars TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN,
-// TWILIO_DEFAULT_TO, and TWILIO_DEFAULT_FROM.
+// go test runs against the fake Twilio API in package utwiltest unless env vars
+// TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN, TWILIO_DEFAULT_TO, and
+// TWILIO_DEFAULT_FROM are populated, in which case it uses the real one.
 //
 // Start with:
 //
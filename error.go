@@ -2,6 +2,7 @@ package utwil
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -37,7 +38,50 @@ func (r RESTException) Error() string {
 	if r.Code != nil {
 		return fmt.Sprintf("Code %d: %s", *r.Code, r.Message)
 	} else if r.Status != nil {
-		return fmt.Sprintf("Status %d: %s", r.Status, r.Message)
+		return fmt.Sprintf("Status %v: %s", r.Status, r.Message)
 	}
 	return r.Message
 }
+
+// httpStatus returns the HTTP status of the RESTException, or 0 if unknown
+func (r RESTException) httpStatus() int {
+	switch status := r.Status.(type) {
+	case int:
+		return status
+	case float64:
+		return int(status)
+	}
+	return 0
+}
+
+// NotFoundError is returned when a requested resource, such as a Call or
+// Message, does not exist.
+type NotFoundError struct {
+	Resource string
+	SID      string
+	RESTException
+}
+
+// Error describes the missing resource
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.SID)
+}
+
+// Unwrap returns the underlying RESTException
+func (e *NotFoundError) Unwrap() error { return e.RESTException }
+
+// IsNotFound reports whether err is, or wraps, a *NotFoundError
+func IsNotFound(err error) bool {
+	var nf *NotFoundError
+	return errors.As(err, &nf)
+}
+
+// notFound converts a 404 RESTException into a *NotFoundError for the
+// resource, returning other errors as they are.
+func notFound(err error, resource, sid string) error {
+	re, ok := err.(RESTException)
+	if ok && (re.httpStatus() == 404 || (re.Code != nil && *re.Code == 20404)) {
+		return &NotFoundError{Resource: resource, SID: sid, RESTException: re}
+	}
+	return err
+}
@@ -1,10 +1,12 @@
 package utwil
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // ListQuery stores query filter configuration and a *utwil.Client,
@@ -46,6 +48,18 @@ func To(phoneNumber string) ListQueryConf {
 	return func(q *ListQuery) { q.Values.Set("To", phoneNumber) }
 }
 
+// Status filters calls, conferences and other resources with a status, e.g.
+// "in-progress".
+func Status(status string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("Status", status) }
+}
+
+// FriendlyName filters conferences, incoming phone numbers and accounts by
+// their friendly name. Twilio ignores it when listing queues.
+func FriendlyName(name string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("FriendlyName", name) }
+}
+
 type listResource struct {
 	Page     int `json:"page"`
 	PageSize int `json:"page_size"`
@@ -62,12 +76,12 @@ type listResource struct {
 	LastPageURI     string  `json:"last_page_uri"`
 }
 
-func (lr listResource) nextPageFullURI() string {
-	return fmt.Sprintf("%s%s", BaseURL, *lr.NextPageURI)
+func (lr listResource) nextPageFullURI(c *Client) string {
+	return fmt.Sprintf("%s%s", c.restURL(), *lr.NextPageURI)
 }
 
-func (lr listResource) loadNextPage(c *Client, result iterable) (iterable, error) {
-	err := c.getJSON(lr.nextPageFullURI(), result)
+func (lr listResource) loadNextPage(ctx context.Context, c *Client, result iterable) (iterable, error) {
+	err := c.getJSON(ctx, lr.nextPageFullURI(c), result)
 	if err != nil {
 		return nil, err
 	}
@@ -83,11 +97,12 @@ type iterable interface {
 	size() int
 
 	hasNextPage() bool
-	nextPage(*Client) (iterable, error)
+	nextPage(context.Context, *Client) (iterable, error)
 }
 
 type iter struct {
 	m        sync.Mutex
+	ctx      context.Context
 	err      error
 	iterable iterable
 	pageItem int
@@ -96,9 +111,10 @@ type iter struct {
 	client   *Client
 }
 
-func newIter(c *Client, initURI string) *iter {
+func newIter(ctx context.Context, c *Client, initURI string) *iter {
 	return &iter{
 		m:        sync.Mutex{},
+		ctx:      ctx,
 		err:      nil,
 		iterable: nil,
 		pageItem: 0,
@@ -115,7 +131,7 @@ func (iter *iter) loadInitURI() error {
 		return fmt.Errorf("initURI uninitialized")
 	}
 
-	err := iter.client.getJSON(iter.initURI, iter.iterable)
+	err := iter.client.getJSON(iter.ctx, iter.initURI, iter.iterable)
 	if err != nil {
 		return err
 	}
@@ -126,6 +142,15 @@ func (iter *iter) next(result interface{}) bool {
 	iter.m.Lock()
 	defer iter.m.Unlock()
 
+	if iter.err != nil {
+		return false
+	}
+	// Stop as soon as the context is done, even mid-page
+	if err := iter.ctx.Err(); err != nil {
+		iter.err = err
+		return false
+	}
+
 	if !iter.didInit {
 		err := iter.loadInitURI()
 		if err != nil {
@@ -140,7 +165,7 @@ func (iter *iter) next(result interface{}) bool {
 		if !iter.iterable.hasNextPage() {
 			return false
 		}
-		nextIter, err := iter.iterable.nextPage(iter.client)
+		nextIter, err := iter.iterable.nextPage(iter.ctx, iter.client)
 		if err != nil {
 			iter.err = err
 			return false
@@ -203,3 +228,135 @@ type CallIter struct{ *iter }
 //	}
 //
 func (iter *CallIter) Next(call *Call) bool { return iter.next(call) }
+
+// MediaIter iterates through the media of a Twilio message.
+type MediaIter struct{ *iter }
+
+// Next attempts to populate media with the next utwil.Media, returning false
+// if it could not due to out of media or an error. It is therefore
+// recommended to check for errors with MediaIter.Err() after use.
+func (iter *MediaIter) Next(media *Media) bool { return iter.next(media) }
+
+// RecordingIter iterates through Twilio recordings.
+type RecordingIter struct{ *iter }
+
+// Next attempts to populate rec with the next utwil.Recording, returning
+// false if it could not due to out of recordings or an error. It is therefore
+// recommended to check for errors with RecordingIter.Err() after use.
+func (iter *RecordingIter) Next(rec *Recording) bool { return iter.next(rec) }
+
+// NotificationIter iterates through Twilio notifications.
+type NotificationIter struct{ *iter }
+
+// Next attempts to populate n with the next utwil.Notification, returning
+// false if it could not due to out of notifications or an error. It is
+// therefore recommended to check for errors with NotificationIter.Err() after
+// use.
+func (iter *NotificationIter) Next(n *Notification) bool { return iter.next(n) }
+
+// ConferenceIter iterates through Twilio conferences.
+type ConferenceIter struct{ *iter }
+
+// Next attempts to populate conf with the next utwil.Conference, returning
+// false if it could not due to out of conferences or an error. It is
+// therefore recommended to check for errors with ConferenceIter.Err() after
+// use.
+func (iter *ConferenceIter) Next(conf *Conference) bool { return iter.next(conf) }
+
+// ParticipantIter iterates through the participants of a Twilio conference.
+type ParticipantIter struct{ *iter }
+
+// Next attempts to populate p with the next utwil.Participant, returning
+// false if it could not due to out of participants or an error. It is
+// therefore recommended to check for errors with ParticipantIter.Err() after
+// use.
+func (iter *ParticipantIter) Next(p *Participant) bool { return iter.next(p) }
+
+// QueueIter iterates through Twilio queues.
+type QueueIter struct{ *iter }
+
+// Next attempts to populate queue with the next utwil.Queue, returning false
+// if it could not due to out of queues or an error. It is therefore
+// recommended to check for errors with QueueIter.Err() after use.
+func (iter *QueueIter) Next(queue *Queue) bool { return iter.next(queue) }
+
+// QueueMemberIter iterates through the members of a Twilio queue.
+type QueueMemberIter struct{ *iter }
+
+// Next attempts to populate member with the next utwil.QueueMember,
+// returning false if it could not due to out of members or an error. It is
+// therefore recommended to check for errors with QueueMemberIter.Err() after
+// use.
+func (iter *QueueMemberIter) Next(member *QueueMember) bool { return iter.next(member) }
+
+// TranscriptionIter iterates through Twilio transcriptions, applying the
+// CreatedBefore and CreatedAfter filters Twilio ignores for them.
+type TranscriptionIter struct {
+	*iter
+	before time.Time // exclusive, zero if unset
+	after  time.Time // inclusive, zero if unset
+	done   bool
+}
+
+// Next attempts to populate tr with the next utwil.Transcription, returning
+// false if it could not due to out of transcriptions or an error. It is
+// therefore recommended to check for errors with TranscriptionIter.Err()
+// after use.
+func (iter *TranscriptionIter) Next(tr *Transcription) bool {
+	iter.m.Lock()
+	done := iter.done
+	iter.m.Unlock()
+	if done {
+		return false
+	}
+
+	for iter.next(tr) {
+		switch {
+		case iter.before.IsZero() && iter.after.IsZero():
+			return true
+		case tr.DateCreated == nil:
+			continue
+		case !iter.after.IsZero() && tr.DateCreated.Before(iter.after):
+			// Transcriptions are listed newest first, so the rest are
+			// older still
+			iter.m.Lock()
+			iter.done = true
+			iter.m.Unlock()
+			return false
+		case !iter.before.IsZero() && !tr.DateCreated.Before(iter.before):
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// IncomingPhoneNumberIter iterates through the phone numbers of a Twilio
+// account.
+type IncomingPhoneNumberIter struct{ *iter }
+
+// Next attempts to populate number with the next utwil.IncomingPhoneNumber,
+// returning false if it could not due to out of phone numbers or an error. It
+// is therefore recommended to check for errors with
+// IncomingPhoneNumberIter.Err() after use.
+func (iter *IncomingPhoneNumberIter) Next(number *IncomingPhoneNumber) bool { return iter.next(number) }
+
+// AvailablePhoneNumberIter iterates through the phone numbers found in
+// Twilio's inventory.
+type AvailablePhoneNumberIter struct{ *iter }
+
+// Next attempts to populate number with the next utwil.AvailablePhoneNumber,
+// returning false if it could not due to out of phone numbers or an error. It
+// is therefore recommended to check for errors with
+// AvailablePhoneNumberIter.Err() after use.
+func (iter *AvailablePhoneNumberIter) Next(number *AvailablePhoneNumber) bool {
+	return iter.next(number)
+}
+
+// AccountIter iterates through Twilio accounts.
+type AccountIter struct{ *iter }
+
+// Next attempts to populate account with the next utwil.Account, returning
+// false if it could not due to out of accounts or an error. It is therefore
+// recommended to check for errors with AccountIter.Err() after use.
+func (iter *AccountIter) Next(account *Account) bool { return iter.next(account) }
@@ -1,10 +1,13 @@
 package utwil
 
 import (
+	"context"
 	"log"
 	"os"
 	"testing"
 	"time"
+
+	"github.com/wyc/utwil/utwiltest"
 )
 
 var (
@@ -12,11 +15,19 @@ var (
 	AuthToken       = os.Getenv("TWILIO_AUTH_TOKEN")
 	ToPhoneNumber   = os.Getenv("TWILIO_DEFAULT_TO")
 	FromPhoneNumber = os.Getenv("TWILIO_DEFAULT_FROM")
-	TestClient      = NewClient(AccountSID, AuthToken)
+	TestClient      Client
+
+	// TestServer is the fake Twilio API the tests run against when the
+	// env vars are unset, and nil when testing against the real one.
+	TestServer *utwiltest.Server
 )
 
-func init() {
-	if AccountSID == "" {
+func TestMain(m *testing.M) {
+	if AccountSID == "" && AuthToken == "" {
+		TestServer = utwiltest.NewServer()
+		AccountSID, AuthToken = TestServer.AccountSID, TestServer.AuthToken
+		ToPhoneNumber, FromPhoneNumber = "+15553214321", "+15551231234"
+	} else if AccountSID == "" {
 		log.Fatalf("Testing env var TWILIO_ACCOUNT_SID is unset")
 	} else if AuthToken == "" {
 		log.Fatalf("Testing env var TWILIO_AUTH_TOKEN is unset")
@@ -25,6 +36,27 @@ func init() {
 	} else if FromPhoneNumber == "" {
 		log.Fatalf("Testing env var TWILIO_DEFAULT_FROM is unset")
 	}
+
+	TestClient = NewClient(AccountSID, AuthToken)
+	if TestServer != nil {
+		TestClient.BaseURL = TestServer.URL
+		TestClient.LookupURL = TestServer.LookupURL()
+	}
+	code := m.Run()
+	if TestServer != nil {
+		TestServer.Close()
+	}
+	os.Exit(code)
+}
+
+// requireFake skips tests that need to seed or inspect the fake Twilio API,
+// or that would be destructive against a real account.
+func requireFake(t *testing.T) *utwiltest.Server {
+	t.Helper()
+	if TestServer == nil {
+		t.Skip("needs the fake Twilio API; unset TWILIO_ACCOUNT_SID and TWILIO_AUTH_TOKEN")
+	}
+	return TestServer
 }
 
 // Iterate (and paginate) through all the calls
@@ -45,8 +77,20 @@ func TestListCalls(t *testing.T) {
 // one week
 func TestQueryCalls(t *testing.T) {
 	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	from, expected := FromPhoneNumber, -1
+	if TestServer != nil {
+		// Two calls within the week and one before it, from a number no
+		// other test calls from
+		from, expected = "+15550002222", 2
+		for _, started := range []time.Time{time.Now(), time.Now(), weekAgo.AddDate(0, 0, -2)} {
+			TestServer.Add("Calls", utwiltest.Resource{
+				"from":       from,
+				"start_time": started.UTC().Format(time.RFC1123Z),
+			})
+		}
+	}
 	iter := TestClient.Calls(
-		From(FromPhoneNumber),
+		From(from),
 		StartedAfterYMD(weekAgo)).Iter()
 	callCount := 0
 	var call Call
@@ -56,6 +100,9 @@ func TestQueryCalls(t *testing.T) {
 	if iter.Err() != nil {
 		t.Fatalf("error: %s", iter.Err().Error())
 	}
+	if expected >= 0 && callCount != expected {
+		t.Fatalf("expected %d calls within one week, got %d", expected, callCount)
+	}
 	t.Logf("Within-one-week calls total: %d\n", callCount)
 }
 
@@ -77,8 +124,23 @@ func TestListMessages(t *testing.T) {
 // one week
 func TestQueryMessages(t *testing.T) {
 	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	from, expected := FromPhoneNumber, -1
+	if TestServer != nil {
+		// Two messages sent now and one sent before the week, from a
+		// number no other test sends from
+		from, expected = "+15550003333", 2
+		for i := 0; i < 2; i++ {
+			if _, err := TestClient.SendSMS(from, ToPhoneNumber, "Hello, world!"); err != nil {
+				t.Fatalf("Failed: %s", err.Error())
+			}
+		}
+		TestServer.Add("Messages", utwiltest.Resource{
+			"from":      from,
+			"date_sent": weekAgo.AddDate(0, 0, -2).UTC().Format(time.RFC1123Z),
+		})
+	}
 	iter := TestClient.Messages(
-		From(FromPhoneNumber),
+		From(from),
 		SentAfterYMD(weekAgo)).Iter()
 	msgCount := 0
 	var msg Message
@@ -88,5 +150,48 @@ func TestQueryMessages(t *testing.T) {
 	if iter.Err() != nil {
 		t.Fatalf("error: %s\n", iter.Err().Error())
 	}
+	if expected >= 0 && msgCount != expected {
+		t.Fatalf("expected %d messages within one week, got %d", expected, msgCount)
+	}
 	t.Logf("With-one-week Messages total: %d\n", msgCount)
 }
+
+// A cancelled context stops iteration before any page is requested
+func TestListCallsContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	iter := TestClient.Calls().IterContext(ctx)
+	var call Call
+	if iter.Next(&call) {
+		t.Fatalf("Next() succeeded with a cancelled context")
+	}
+	if iter.Err() != context.Canceled {
+		t.Fatalf("expected context.Canceled, got: %v", iter.Err())
+	}
+}
+
+// Pagination follows next_page_uri until every page has been read
+func TestListMessagesPaging(t *testing.T) {
+	srv := requireFake(t)
+	from := "+15550001111"
+	for i := 0; i < 7; i++ {
+		srv.Add("Messages", utwiltest.Resource{"from": from, "to": ToPhoneNumber})
+	}
+	srv.ResetRequests()
+
+	q := TestClient.Messages(From(from))
+	q.Set("PageSize", "3")
+	iter := q.Iter()
+	msgCount := 0
+	var msg Message
+	for iter.Next(&msg) {
+		msgCount++
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s\n", iter.Err().Error())
+	}
+	if msgCount != 7 {
+		t.Fatalf("expected 7 messages, got %d", msgCount)
+	}
+	srv.AssertRequestCount(t, "GET", "/Messages.json", 3)
+}
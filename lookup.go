@@ -1,6 +1,7 @@
 package utwil
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -19,6 +20,12 @@ type LookupReq struct {
 // SubmitLookup sends a lookup request populating form fields only if they
 // contain a non-zero value.
 func (c *Client) SubmitLookup(req LookupReq) (Lookup, error) {
+	return c.SubmitLookupContext(context.Background(), req)
+}
+
+// SubmitLookupContext is the same as Client.SubmitLookup, but the request is
+// bound to ctx.
+func (c *Client) SubmitLookupContext(ctx context.Context, req LookupReq) (Lookup, error) {
 	// @TODO wait until github.com/gorilla/schema supports struct-to-url.Values
 	values := url.Values{}
 	if req.Type != "" {
@@ -27,9 +34,9 @@ func (c *Client) SubmitLookup(req LookupReq) (Lookup, error) {
 	if req.CountryCode != "" {
 		values.Add("CountryCode", req.CountryCode)
 	}
-	url := fmt.Sprintf("%s/PhoneNumbers/%s?%s", LookupURL, req.PhoneNumber, values.Encode())
+	url := fmt.Sprintf("%s/PhoneNumbers/%s?%s", c.lookupURL(), req.PhoneNumber, values.Encode())
 	res := Lookup{}
-	err := c.getJSON(url, &res)
+	err := c.getJSON(ctx, url, &res)
 	return res, err
 }
 
@@ -62,15 +69,26 @@ type Lookup struct {
 //      fmt.Println(lookup.Carrier.Type) // "mobile", "landline", or "voip"
 //
 func (c *Client) Lookup(phoneNumber string) (Lookup, error) {
+	return c.LookupContext(context.Background(), phoneNumber)
+}
+
+// LookupContext is the same as Client.Lookup, but the request is bound to ctx.
+func (c *Client) LookupContext(ctx context.Context, phoneNumber string) (Lookup, error) {
 	req := LookupReq{
 		PhoneNumber: phoneNumber,
 		Type:        "carrier",
 	}
-	return c.SubmitLookup(req)
+	return c.SubmitLookupContext(ctx, req)
 }
 
 // LookupNoCarrier looks up a phone number's details without the carrier
 func (c *Client) LookupNoCarrier(phoneNumber string) (Lookup, error) {
+	return c.LookupNoCarrierContext(context.Background(), phoneNumber)
+}
+
+// LookupNoCarrierContext is the same as Client.LookupNoCarrier, but the
+// request is bound to ctx.
+func (c *Client) LookupNoCarrierContext(ctx context.Context, phoneNumber string) (Lookup, error) {
 	req := LookupReq{PhoneNumber: phoneNumber}
-	return c.SubmitLookup(req)
+	return c.SubmitLookupContext(ctx, req)
 }
@@ -0,0 +1,112 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Media is the Go-representation of Twilio REST API's media, a file attached
+// to an MMS message.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/sms/api/media-resource
+type Media struct {
+	AccountSID  string `json:"account_sid"`
+	ContentType string `json:"content_type"`
+	DateCreated *Time  `json:"date_created"`
+	DateUpdated *Time  `json:"date_updated"`
+	ParentSID   string `json:"parent_sid"`
+	SID         string `json:"sid"`
+	URI         string `json:"uri"`
+}
+
+func (c *Client) mediaListURL(msgSID string) string {
+	return fmt.Sprintf("%s/Messages/%s/Media.json", c.urlPrefix(), msgSID)
+}
+
+// mediaURL is the URL of the media's content; its metadata is at ".json"
+func (c *Client) mediaURL(msgSID, mediaSID string) string {
+	return fmt.Sprintf("%s/Messages/%s/Media/%s", c.urlPrefix(), msgSID, mediaSID)
+}
+
+// MediaListQuery is a struct that contains an embedded utwil.ListQuery.
+// The typing allows the correctly-typed iterator/list to be returned.
+type MediaListQuery struct {
+	*ListQuery
+	messageSID string
+}
+
+// MessageMedia takes the SID of a message and a vargs of utwil.ListQueryConf
+// functions to configure a query for the media attached to it:
+//
+//	iter := client.MessageMedia(msg.SID).Iter()
+//	var media utwil.Media
+//	for iter.Next(&media) {
+//		contentType, err := client.DownloadMedia(msg.SID, media.SID, file)
+//	}
+func (c *Client) MessageMedia(msgSID string, confs ...ListQueryConf) *MediaListQuery {
+	return &MediaListQuery{ListQuery: newListQuery(c, confs...), messageSID: msgSID}
+}
+
+// Iter creates an iterator that iterates utwil.Media results
+func (q *MediaListQuery) Iter() *MediaIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as MediaListQuery.Iter, but every page is fetched
+// with ctx and iteration stops once ctx is done.
+func (q *MediaListQuery) IterContext(ctx context.Context) *MediaIter {
+	initURI := fmt.Sprintf("%s?%s", q.mediaListURL(q.messageSID), q.Values.Encode())
+	iter := &MediaIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &mediaList{}
+	return iter
+}
+
+type mediaList struct {
+	Media []Media `json:"media_list"`
+	listResource
+}
+
+func (ml mediaList) item(idx int) interface{} { return ml.Media[idx] }
+func (ml mediaList) size() int                { return len(ml.Media) }
+func (ml mediaList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return ml.loadNextPage(ctx, c, &mediaList{})
+}
+
+// DownloadMedia streams the content of a message's media to w and returns
+// its content type.
+func (c *Client) DownloadMedia(msgSID, mediaSID string, w io.Writer) (string, error) {
+	return c.DownloadMediaContext(context.Background(), msgSID, mediaSID, w)
+}
+
+// DownloadMediaContext is the same as Client.DownloadMedia, but the request
+// is bound to ctx.
+func (c *Client) DownloadMediaContext(ctx context.Context, msgSID, mediaSID string, w io.Writer) (string, error) {
+	return c.download(ctx, c.mediaURL(msgSID, mediaSID), w, "Media", mediaSID)
+}
+
+// download streams the content at url to w and returns its content type
+func (c *Client) download(ctx context.Context, url string, w io.Writer, resource, sid string) (string, error) {
+	resp, err := c.do(ctx, "GET", url, nil)
+	if err != nil {
+		return "", notFound(err, resource, sid)
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return resp.Header.Get("Content-Type"), err
+}
+
+// DeleteMedia deletes a media file from a message. A *NotFoundError is
+// returned if there is no such media.
+func (c *Client) DeleteMedia(msgSID, mediaSID string) error {
+	return c.DeleteMediaContext(context.Background(), msgSID, mediaSID)
+}
+
+// DeleteMediaContext is the same as Client.DeleteMedia, but the request is
+// bound to ctx.
+func (c *Client) DeleteMediaContext(ctx context.Context, msgSID, mediaSID string) error {
+	err := c.delete(ctx, c.mediaURL(msgSID, mediaSID)+".json")
+	return notFound(err, "Media", mediaSID)
+}
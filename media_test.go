@@ -0,0 +1,49 @@
+package utwil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestMessageMedia(t *testing.T) {
+	srv := requireFake(t)
+	msgSID := srv.Add("Messages", utwiltest.Resource{"num_media": "2"})["sid"].(string)
+	mediaPath := "Messages/" + msgSID + "/Media"
+	png := srv.Add(mediaPath, utwiltest.Resource{"content_type": "image/png", "parent_sid": msgSID})
+	srv.Add(mediaPath, utwiltest.Resource{"content_type": "image/jpeg", "parent_sid": msgSID})
+	srv.SetContent(mediaPath+"/"+png["sid"].(string), "image/png", []byte("\x89PNG"))
+
+	iter := TestClient.MessageMedia(msgSID).Iter()
+	var media Media
+	var all []Media
+	for iter.Next(&media) {
+		all = append(all, media)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if len(all) != 2 || all[1].SID != png["sid"] || all[1].ParentSID != msgSID {
+		t.Fatalf("unexpected media: %+v", all)
+	}
+
+	var buf bytes.Buffer
+	contentType, err := TestClient.DownloadMedia(msgSID, all[1].SID, &buf)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if contentType != "image/png" || buf.String() != "\x89PNG" {
+		t.Fatalf("unexpected content: %s %q", contentType, buf.String())
+	}
+
+	if err := TestClient.DeleteMedia(msgSID, all[1].SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if err := TestClient.DeleteMedia(msgSID, all[1].SID); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+	if _, err := TestClient.DownloadMedia(msgSID, all[0].SID, &buf); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package utwil
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -43,24 +46,143 @@ type Message struct {
 //      https://www.twilio.com/docs/api/rest/sending-messages
 //
 type MessageReq struct {
-	From           string
-	To             string
-	Body           string
-	MediaURL       string
-	StatusCallback string
-	ApplicationSID string
+	From                string
+	MessagingServiceSID string
+	To                  string
+	Body                string
+	MediaURL            string
+	MediaURLs           []string
+	ContentSID          string
+	ContentVariables    map[string]string
+	StatusCallback      string
+	ApplicationSID      string
+	MaxPrice            string
+	ProvideFeedback     bool
+	ValidityPeriod      int
+	ForceDelivery       bool
+	SmartEncoded        bool
+	PersistentAction    []string
+	ShortenURLs         bool
+	SendAsMMS           bool
+	Attempt             int
+	ScheduleType        string
+	SendAt              time.Time
 }
 
-// SubmitMessage sends a message request populating form fields only if they contain
-// a non-zero value.
+// MaxMediaURLs is the number of media files Twilio accepts per message
+const MaxMediaURLs = 10
+
+// MaxValidityPeriod is the longest ValidityPeriod, in seconds, Twilio accepts
+const MaxValidityPeriod = 36000
+
+// ScheduleFixed is the ScheduleType of messages sent at a fixed SendAt time
+const ScheduleFixed = "fixed"
+
+// Scheduled messages must be sent at least MinScheduleAhead and at most
+// MaxScheduleAhead from the time they are submitted.
+const (
+	MinScheduleAhead = 15 * time.Minute
+	MaxScheduleAhead = 35 * 24 * time.Hour
+)
+
+// AllMediaURLs returns MediaURL, if set, followed by MediaURLs.
+func (req MessageReq) AllMediaURLs() []string {
+	if req.MediaURL == "" {
+		return req.MediaURLs
+	}
+	return append([]string{req.MediaURL}, req.MediaURLs...)
+}
+
+// Validate checks the request for errors Twilio would reject it for, so they
+// are caught before anything is sent. SubmitMessage calls it first.
+func (req MessageReq) Validate() error {
+	numMedia := len(req.AllMediaURLs())
+	switch {
+	case req.From == "" && req.MessagingServiceSID == "":
+		return fmt.Errorf("utwil: either From or MessagingServiceSID is required")
+	case req.Body == "" && numMedia == 0 && req.ContentSID == "":
+		return fmt.Errorf("utwil: one of Body, MediaURL(s) or ContentSID is required")
+	case req.ContentSID != "" && (req.Body != "" || numMedia > 0):
+		return fmt.Errorf("utwil: ContentSID cannot be combined with Body or MediaURL(s)")
+	case req.ContentVariables != nil && req.ContentSID == "":
+		return fmt.Errorf("utwil: ContentVariables requires ContentSID")
+	case req.ApplicationSID != "" && req.StatusCallback != "":
+		return fmt.Errorf("utwil: ApplicationSID and StatusCallback are mutually exclusive")
+	case req.ShortenURLs && req.MessagingServiceSID == "":
+		return fmt.Errorf("utwil: ShortenURLs requires MessagingServiceSID")
+	case numMedia > MaxMediaURLs:
+		return fmt.Errorf("utwil: %d media URLs exceed the limit of %d per message", numMedia, MaxMediaURLs)
+	case req.ValidityPeriod < 0 || req.ValidityPeriod > MaxValidityPeriod:
+		return fmt.Errorf("utwil: ValidityPeriod must be between 0 (unset) and %d seconds", MaxValidityPeriod)
+	case req.Attempt < 0:
+		return fmt.Errorf("utwil: Attempt cannot be negative")
+	}
+	return req.validateSchedule(time.Now())
+}
+
+func (req MessageReq) validateSchedule(now time.Time) error {
+	if req.ScheduleType == "" && req.SendAt.IsZero() {
+		return nil
+	}
+	switch {
+	case req.ScheduleType != ScheduleFixed:
+		return fmt.Errorf("utwil: SendAt requires ScheduleType %q", ScheduleFixed)
+	case req.SendAt.IsZero():
+		return fmt.Errorf("utwil: ScheduleType %q requires SendAt", ScheduleFixed)
+	case req.MessagingServiceSID == "":
+		return fmt.Errorf("utwil: scheduled messages require MessagingServiceSID")
+	case req.SendAt.Before(now.Add(MinScheduleAhead)) || req.SendAt.After(now.Add(MaxScheduleAhead)):
+		return fmt.Errorf("utwil: SendAt must be between %s and %s from now",
+			MinScheduleAhead, MaxScheduleAhead)
+	}
+	return nil
+}
+
+// sender is the number or messaging service the message is rate limited by
+func (req MessageReq) sender() string {
+	if req.From != "" {
+		return req.From
+	}
+	return req.MessagingServiceSID
+}
+
+// SubmitMessage validates and sends a message request populating form fields
+// only if they contain a non-zero value.
 func (c *Client) SubmitMessage(req MessageReq) (Message, error) {
+	return c.SubmitMessageContext(context.Background(), req)
+}
+
+// SubmitMessageContext is the same as Client.SubmitMessage, but the request is
+// bound to ctx.
+func (c *Client) SubmitMessageContext(ctx context.Context, req MessageReq) (Message, error) {
+	var msg Message
+	if err := req.Validate(); err != nil {
+		return msg, err
+	}
 	// @TODO wait until github.com/gorilla/schema supports struct-to-url.Values
 	values := url.Values{}
-	values.Set("From", req.From)
+	if req.From != "" {
+		values.Set("From", req.From)
+	}
+	if req.MessagingServiceSID != "" {
+		values.Set("MessagingServiceSid", req.MessagingServiceSID)
+	}
 	values.Set("To", req.To)
-	values.Set("Body", req.Body)
-	if req.MediaURL != "" {
-		values.Set("MediaUrl", req.MediaURL)
+	if req.Body != "" {
+		values.Set("Body", req.Body)
+	}
+	for _, mediaURL := range req.AllMediaURLs() {
+		values.Add("MediaUrl", mediaURL)
+	}
+	if req.ContentSID != "" {
+		values.Set("ContentSid", req.ContentSID)
+	}
+	if req.ContentVariables != nil {
+		vars, err := json.Marshal(req.ContentVariables)
+		if err != nil {
+			return msg, err
+		}
+		values.Set("ContentVariables", string(vars))
 	}
 	if req.StatusCallback != "" {
 		values.Set("StatusCallback", req.StatusCallback)
@@ -68,8 +190,43 @@ func (c *Client) SubmitMessage(req MessageReq) (Message, error) {
 	if req.ApplicationSID != "" {
 		values.Set("ApplicationSid", req.ApplicationSID)
 	}
-	var msg Message
-	err := c.postForm(fmt.Sprintf("%s/Messages.json", c.urlPrefix()), values, &msg)
+	if req.MaxPrice != "" {
+		values.Set("MaxPrice", req.MaxPrice)
+	}
+	if req.ProvideFeedback {
+		values.Set("ProvideFeedback", "true")
+	}
+	if req.ValidityPeriod > 0 {
+		values.Set("ValidityPeriod", strconv.Itoa(req.ValidityPeriod))
+	}
+	if req.ForceDelivery {
+		values.Set("ForceDelivery", "true")
+	}
+	if req.SmartEncoded {
+		values.Set("SmartEncoded", "true")
+	}
+	for _, action := range req.PersistentAction {
+		values.Add("PersistentAction", action)
+	}
+	if req.ShortenURLs {
+		values.Set("ShortenUrls", "true")
+	}
+	if req.SendAsMMS {
+		values.Set("SendAsMms", "true")
+	}
+	if req.Attempt > 0 {
+		values.Set("Attempt", strconv.Itoa(req.Attempt))
+	}
+	if req.ScheduleType != "" {
+		values.Set("ScheduleType", req.ScheduleType)
+	}
+	if !req.SendAt.IsZero() {
+		values.Set("SendAt", req.SendAt.UTC().Format(time.RFC3339))
+	}
+	if err := c.throttle(ctx, req.sender()); err != nil {
+		return msg, err
+	}
+	err := c.postForm(ctx, c.messagesURL(), values, &msg)
 	return msg, err
 }
 
@@ -80,7 +237,13 @@ func (c *Client) SubmitMessage(req MessageReq) (Message, error) {
 //	msg, err := client.SendSMS("+15551231234", "+15553214321", "Hello, world!")
 //
 func (c *Client) SendSMS(from, to, body string) (Message, error) {
-	return c.SendMMS(from, to, body, "")
+	return c.SendSMSContext(context.Background(), from, to, body)
+}
+
+// SendSMSContext is the same as Client.SendSMS, but the request is bound to
+// ctx.
+func (c *Client) SendSMSContext(ctx context.Context, from, to, body string) (Message, error) {
+	return c.SendMMSContext(ctx, from, to, body, "")
 }
 
 // SendMMS sends body and mediaURL from/to the specified number.
@@ -92,13 +255,90 @@ func (c *Client) SendSMS(from, to, body string) (Message, error) {
 //      msg, err := client.SendMMS("+15551231234", "+15553214321", body, mediaURL)
 //
 func (c *Client) SendMMS(from, to, body, mediaURL string) (Message, error) {
+	return c.SendMMSContext(context.Background(), from, to, body, mediaURL)
+}
+
+// SendMMSContext is the same as Client.SendMMS, but the request is bound to
+// ctx.
+func (c *Client) SendMMSContext(ctx context.Context, from, to, body, mediaURL string) (Message, error) {
 	req := MessageReq{
 		From:     from,
 		To:       to,
 		Body:     body,
 		MediaURL: mediaURL,
 	}
-	return c.SubmitMessage(req)
+	return c.SubmitMessageContext(ctx, req)
+}
+
+// GetMessage fetches the message with the given SID. A *NotFoundError is
+// returned if there is no such message.
+func (c *Client) GetMessage(sid string) (Message, error) {
+	return c.GetMessageContext(context.Background(), sid)
+}
+
+// GetMessageContext is the same as Client.GetMessage, but the request is
+// bound to ctx.
+func (c *Client) GetMessageContext(ctx context.Context, sid string) (Message, error) {
+	var msg Message
+	err := c.getJSON(ctx, c.messageURL(sid), &msg)
+	return msg, notFound(err, "Message", sid)
+}
+
+// DeleteMessage deletes the message with the given SID from the account's
+// logs. A *NotFoundError is returned if there is no such message.
+func (c *Client) DeleteMessage(sid string) error {
+	return c.DeleteMessageContext(context.Background(), sid)
+}
+
+// DeleteMessageContext is the same as Client.DeleteMessage, but the request
+// is bound to ctx.
+func (c *Client) DeleteMessageContext(ctx context.Context, sid string) error {
+	return notFound(c.delete(ctx, c.messageURL(sid)), "Message", sid)
+}
+
+// RedactMessage erases the body of the message with the given SID, keeping
+// the rest of its record.
+func (c *Client) RedactMessage(sid string) (Message, error) {
+	return c.RedactMessageContext(context.Background(), sid)
+}
+
+// RedactMessageContext is the same as Client.RedactMessage, but the request
+// is bound to ctx.
+func (c *Client) RedactMessageContext(ctx context.Context, sid string) (Message, error) {
+	values := url.Values{}
+	values.Set("Body", "")
+	var msg Message
+	err := c.postForm(ctx, c.messageURL(sid), values, &msg)
+	return msg, notFound(err, "Message", sid)
+}
+
+// CancelScheduledMessage cancels a message scheduled with ScheduleType and
+// SendAt that has not been sent yet.
+//
+// Example:
+//
+//	msg, err := client.SubmitMessage(utwil.MessageReq{
+//		MessagingServiceSID: "MG...",
+//		To:                  "+15553214321",
+//		Body:                "Your appointment is tomorrow at 9am.",
+//		ScheduleType:        utwil.ScheduleFixed,
+//		SendAt:              appointment.Add(-24 * time.Hour),
+//	})
+//	// ...
+//	msg, err = client.CancelScheduledMessage(msg.SID)
+//
+func (c *Client) CancelScheduledMessage(sid string) (Message, error) {
+	return c.CancelScheduledMessageContext(context.Background(), sid)
+}
+
+// CancelScheduledMessageContext is the same as Client.CancelScheduledMessage,
+// but the request is bound to ctx.
+func (c *Client) CancelScheduledMessageContext(ctx context.Context, sid string) (Message, error) {
+	values := url.Values{}
+	values.Set("Status", string(MessageCanceled))
+	var msg Message
+	err := c.postForm(ctx, c.messageURL(sid), values, &msg)
+	return msg, notFound(err, "Message", sid)
 }
 
 // MessageListQuery is a struct that contains an embedded utwil.ListQuery.
@@ -138,9 +378,15 @@ func SentAfterYMD(t time.Time) ListQueryConf {
 
 // Iter creates an iterator that iterates utwil.Message results
 func (q *MessageListQuery) Iter() *MessageIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as MessageListQuery.Iter, but every page is fetched
+// with ctx and iteration stops once ctx is done.
+func (q *MessageListQuery) IterContext(ctx context.Context) *MessageIter {
 	initURI := fmt.Sprintf("%s?%s", q.messagesURL(), q.Values.Encode())
-	iter := &MessageIter{iter: newIter(q.Client, initURI)}
-	iter.iterable = messageList{}
+	iter := &MessageIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &messageList{}
 	return iter
 }
 
@@ -157,6 +403,79 @@ func (ml messageList) size() int {
 	return len(ml.Messages)
 }
 
-func (ml messageList) nextPage(c *Client) (iterable, error) {
-	return ml.loadNextPage(c, &messageList{})
+func (ml messageList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return ml.loadNextPage(ctx, c, &messageList{})
+}
+
+// BulkReport is the outcome of applying an operation to every message
+// matched by a MessageListQuery.
+type BulkReport struct {
+	// Succeeded lists the SIDs the operation succeeded for
+	Succeeded []string
+	// Failed maps the SIDs the operation failed for to their error
+	Failed map[string]error
+	// Err is set if listing the messages failed, in which case the report
+	// only covers those listed before the failure.
+	Err error
+}
+
+// OK reports whether the operation succeeded for every matched message.
+func (r *BulkReport) OK() bool { return r.Err == nil && len(r.Failed) == 0 }
+
+// RedactAll redacts every message matched by the query. Matches are listed
+// before any is modified, so pagination is not disturbed.
+//
+// Example:
+//
+//	monthAgo := time.Now().Add(-30 * 24 * time.Hour)
+//	report := client.Messages(utwil.SentBeforeYMD(monthAgo)).RedactAll()
+//	if !report.OK() {
+//		// inspect report.Failed and report.Err
+//	}
+//
+func (q *MessageListQuery) RedactAll() *BulkReport {
+	return q.RedactAllContext(context.Background())
+}
+
+// RedactAllContext is the same as MessageListQuery.RedactAll, but every
+// request is bound to ctx.
+func (q *MessageListQuery) RedactAllContext(ctx context.Context) *BulkReport {
+	return q.bulk(ctx, func(sid string) error {
+		_, err := q.Client.RedactMessageContext(ctx, sid)
+		return err
+	})
+}
+
+// DeleteAll deletes every message matched by the query. Matches are listed
+// before any is deleted, so pagination is not disturbed.
+func (q *MessageListQuery) DeleteAll() *BulkReport {
+	return q.DeleteAllContext(context.Background())
+}
+
+// DeleteAllContext is the same as MessageListQuery.DeleteAll, but every
+// request is bound to ctx.
+func (q *MessageListQuery) DeleteAllContext(ctx context.Context) *BulkReport {
+	return q.bulk(ctx, func(sid string) error {
+		return q.Client.DeleteMessageContext(ctx, sid)
+	})
+}
+
+func (q *MessageListQuery) bulk(ctx context.Context, op func(sid string) error) *BulkReport {
+	report := &BulkReport{Failed: make(map[string]error)}
+	var sids []string
+	iter := q.IterContext(ctx)
+	var msg Message
+	for iter.Next(&msg) {
+		sids = append(sids, msg.SID)
+	}
+	report.Err = iter.Err()
+
+	for _, sid := range sids {
+		if err := op(sid); err != nil {
+			report.Failed[sid] = err
+		} else {
+			report.Succeeded = append(report.Succeeded, sid)
+		}
+	}
+	return report
 }
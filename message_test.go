@@ -2,7 +2,11 @@ package utwil
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/wyc/utwil/utwiltest"
 )
 
 // This test sends a test SMS to ToPhoneNumber
@@ -18,3 +22,250 @@ func TestSendSMS(t *testing.T) {
 	t.Logf("Message Sent:\n%s\n", string(bs))
 
 }
+
+// A RESTException is returned for rejected requests
+func TestSendSMSError(t *testing.T) {
+	requireFake(t)
+	_, err := TestClient.SendSMS(FromPhoneNumber, "", "Hello, world!")
+	re, ok := err.(RESTException)
+	if !ok {
+		t.Fatalf("expected RESTException, got: %v", err)
+	}
+	if re.Code == nil || *re.Code != 21604 {
+		t.Fatalf("unexpected error: %s", re.Error())
+	}
+}
+
+func TestGetMessage(t *testing.T) {
+	sent, err := TestClient.SendSMS(FromPhoneNumber, ToPhoneNumber, "Hello again, world!")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	msg, err := TestClient.GetMessage(sent.SID)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if msg.SID != sent.SID || msg.Body != sent.Body {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+
+	_, err = TestClient.GetMessage("SM00000000000000000000000000000000")
+	if nf, ok := err.(*NotFoundError); !ok || nf.SID != "SM00000000000000000000000000000000" {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
+
+func TestRedactAndDeleteMessage(t *testing.T) {
+	srv := requireFake(t)
+	sid := srv.Add("Messages", utwiltest.Resource{"body": "secret"})["sid"].(string)
+
+	msg, err := TestClient.RedactMessage(sid)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if msg.Body != "" {
+		t.Fatalf("body was not redacted: %+v", msg)
+	}
+	if req := srv.AssertRequested(t, "POST", "/Messages/"+sid+".json"); req.Form.Encode() != "Body=" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+
+	if err := TestClient.DeleteMessage(sid); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, ok := srv.Get("Messages", sid); ok {
+		t.Fatalf("message was not deleted")
+	}
+	if err := TestClient.DeleteMessage(sid); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
+
+func TestMessagesRedactAll(t *testing.T) {
+	srv := requireFake(t)
+	from := "+15550002222"
+	for i := 0; i < 5; i++ {
+		srv.Add("Messages", utwiltest.Resource{"from": from, "body": "secret"})
+	}
+	q := TestClient.Messages(From(from))
+	q.Set("PageSize", "2")
+
+	srv.FailNext(400, 20001, "Bad Request")
+	report := q.RedactAll()
+	if report.Err == nil {
+		t.Fatalf("expected the listing error to be reported")
+	}
+
+	report = q.RedactAll()
+	if !report.OK() || len(report.Succeeded) != 5 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	for _, msg := range srv.List("Messages") {
+		if msg["from"] == from && msg["body"] != "" {
+			t.Fatalf("message was not redacted: %v", msg)
+		}
+	}
+
+	report = q.DeleteAll()
+	if !report.OK() || len(report.Succeeded) != 5 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestSubmitMessageMediaURLs(t *testing.T) {
+	srv := requireFake(t)
+	req := MessageReq{
+		From:      FromPhoneNumber,
+		To:        ToPhoneNumber,
+		MediaURL:  "https://example.com/0.png",
+		MediaURLs: []string{"https://example.com/1.png", "https://example.com/2.png"},
+	}
+	msg, err := TestClient.SubmitMessage(req)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	form := srv.AssertRequested(t, "POST", "/Messages.json").Form
+	if len(form["MediaUrl"]) != 3 || form["MediaUrl"][0] != req.MediaURL || msg.NumMedia != "3" {
+		t.Fatalf("unexpected form: %v", form)
+	}
+
+	srv.ResetRequests()
+	for len(req.MediaURLs) < MaxMediaURLs {
+		req.MediaURLs = append(req.MediaURLs, "https://example.com/n.png")
+	}
+	if _, err := TestClient.SubmitMessage(req); err == nil {
+		t.Fatalf("%d media URLs were accepted", len(req.AllMediaURLs()))
+	}
+	srv.AssertRequestCount(t, "POST", "/Messages.json", 0)
+}
+
+// Every optional MessageReq field is sent under Twilio's parameter name
+func TestSubmitMessageParams(t *testing.T) {
+	srv := requireFake(t)
+	for _, test := range []struct {
+		name  string
+		conf  func(*MessageReq)
+		param string
+		want  []string
+	}{
+		{"MessagingServiceSID", func(r *MessageReq) { r.From, r.MessagingServiceSID = "", "MG123" },
+			"MessagingServiceSid", []string{"MG123"}},
+		{"ContentSID", func(r *MessageReq) { r.Body, r.ContentSID = "", "HX123" },
+			"ContentSid", []string{"HX123"}},
+		{"ContentVariables", func(r *MessageReq) {
+			r.Body, r.ContentSID, r.ContentVariables = "", "HX123", map[string]string{"1": "Jenny"}
+		}, "ContentVariables", []string{`{"1":"Jenny"}`}},
+		{"StatusCallback", func(r *MessageReq) { r.StatusCallback = "https://example.com/status" },
+			"StatusCallback", []string{"https://example.com/status"}},
+		{"ApplicationSID", func(r *MessageReq) { r.ApplicationSID = "AP123" },
+			"ApplicationSid", []string{"AP123"}},
+		{"MaxPrice", func(r *MessageReq) { r.MaxPrice = "0.05" }, "MaxPrice", []string{"0.05"}},
+		{"ProvideFeedback", func(r *MessageReq) { r.ProvideFeedback = true },
+			"ProvideFeedback", []string{"true"}},
+		{"ValidityPeriod", func(r *MessageReq) { r.ValidityPeriod = 600 },
+			"ValidityPeriod", []string{"600"}},
+		{"ForceDelivery", func(r *MessageReq) { r.ForceDelivery = true },
+			"ForceDelivery", []string{"true"}},
+		{"SmartEncoded", func(r *MessageReq) { r.SmartEncoded = true },
+			"SmartEncoded", []string{"true"}},
+		{"PersistentAction", func(r *MessageReq) {
+			r.PersistentAction = []string{"mailto:test@example.com", "geo:37.787,-122.401"}
+		}, "PersistentAction", []string{"mailto:test@example.com", "geo:37.787,-122.401"}},
+		{"ShortenURLs", func(r *MessageReq) { r.MessagingServiceSID, r.ShortenURLs = "MG123", true },
+			"ShortenUrls", []string{"true"}},
+		{"SendAsMMS", func(r *MessageReq) { r.SendAsMMS = true }, "SendAsMms", []string{"true"}},
+		{"Attempt", func(r *MessageReq) { r.Attempt = 2 }, "Attempt", []string{"2"}},
+	} {
+		req := MessageReq{From: FromPhoneNumber, To: ToPhoneNumber, Body: "Hello, world!"}
+		test.conf(&req)
+		if _, err := TestClient.SubmitMessage(req); err != nil {
+			t.Fatalf("%s: %s", test.name, err.Error())
+		}
+		form := srv.AssertRequested(t, "POST", "/Messages.json").Form
+		if got := form[test.param]; strings.Join(got, " ") != strings.Join(test.want, " ") {
+			t.Fatalf("%s: expected %s=%q, got %q", test.name, test.param, test.want, got)
+		}
+	}
+}
+
+func TestMessageReqValidate(t *testing.T) {
+	tooManyMedia := make([]string, MaxMediaURLs+1)
+	for i := range tooManyMedia {
+		tooManyMedia[i] = "https://example.com/n.png"
+	}
+	for _, test := range []struct {
+		name string
+		conf func(*MessageReq)
+	}{
+		{"no sender", func(r *MessageReq) { r.From = "" }},
+		{"no content", func(r *MessageReq) { r.Body = "" }},
+		{"ContentSID with Body", func(r *MessageReq) { r.ContentSID = "HX123" }},
+		{"ContentSID with MediaURL", func(r *MessageReq) {
+			r.Body, r.ContentSID, r.MediaURL = "", "HX123", "https://example.com/0.png"
+		}},
+		{"ContentVariables without ContentSID", func(r *MessageReq) {
+			r.ContentVariables = map[string]string{"1": "Jenny"}
+		}},
+		{"ApplicationSID with StatusCallback", func(r *MessageReq) {
+			r.ApplicationSID, r.StatusCallback = "AP123", "https://example.com/status"
+		}},
+		{"ShortenURLs without MessagingServiceSID", func(r *MessageReq) { r.ShortenURLs = true }},
+		{"too many media URLs", func(r *MessageReq) { r.MediaURLs = tooManyMedia }},
+		{"negative ValidityPeriod", func(r *MessageReq) { r.ValidityPeriod = -1 }},
+		{"ValidityPeriod too long", func(r *MessageReq) { r.ValidityPeriod = MaxValidityPeriod + 1 }},
+		{"negative Attempt", func(r *MessageReq) { r.Attempt = -1 }},
+	} {
+		req := MessageReq{From: FromPhoneNumber, To: ToPhoneNumber, Body: "Hello, world!"}
+		test.conf(&req)
+		if err := req.Validate(); err == nil {
+			t.Fatalf("%s: request passed validation", test.name)
+		}
+	}
+}
+
+func TestScheduledMessage(t *testing.T) {
+	srv := requireFake(t)
+	sendAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("PST", -8*60*60))
+	req := MessageReq{
+		MessagingServiceSID: "MG123",
+		To:                  ToPhoneNumber,
+		Body:                "Reminder",
+		ScheduleType:        ScheduleFixed,
+		SendAt:              sendAt,
+	}
+	now := sendAt.Add(-24 * time.Hour)
+	if err := req.validateSchedule(now); err != nil {
+		t.Fatalf("valid schedule rejected: %s", err)
+	}
+	for _, ahead := range []time.Duration{time.Minute, 36 * 24 * time.Hour} {
+		if err := req.validateSchedule(sendAt.Add(-ahead)); err == nil {
+			t.Errorf("SendAt %s ahead was accepted", ahead)
+		}
+	}
+	noService := req
+	noService.MessagingServiceSID, noService.From = "", FromPhoneNumber
+	if err := noService.validateSchedule(now); err == nil {
+		t.Errorf("schedule without MessagingServiceSID was accepted")
+	}
+
+	req.SendAt = time.Now().Add(48 * time.Hour)
+	msg, err := TestClient.SubmitMessage(req)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	form := srv.AssertRequested(t, "POST", "/Messages.json").Form
+	if form.Get("ScheduleType") != "fixed" || form.Get("SendAt") != req.SendAt.UTC().Format(time.RFC3339) {
+		t.Fatalf("unexpected form: %v", form)
+	}
+	if MessageStatus(msg.Status) != MessageScheduled {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+
+	msg, err = TestClient.CancelScheduledMessage(msg.SID)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if MessageStatus(msg.Status) != MessageCanceled {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+}
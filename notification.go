@@ -0,0 +1,160 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Notification is the Go-representation of Twilio REST API's notification,
+// an entry of the account's error log such as a failed webhook request.
+// It is the REST API's view of the debugger; the debugger's alerts, served by
+// Twilio's separate Monitor API, are not covered by utwil.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/notification-resource
+type Notification struct {
+	AccountSID       string   `json:"account_sid"`
+	APIVersion       string   `json:"api_version"`
+	CallSID          string   `json:"call_sid"`
+	DateCreated      *Time    `json:"date_created"`
+	DateUpdated      *Time    `json:"date_updated"`
+	ErrorCode        string   `json:"error_code"`
+	Log              LogLevel `json:"log"`
+	MessageDate      *Time    `json:"message_date"`
+	MessageText      string   `json:"message_text"`
+	MoreInfo         string   `json:"more_info"`
+	RequestMethod    string   `json:"request_method"`
+	RequestURL       string   `json:"request_url"`
+	RequestVariables string   `json:"request_variables"`
+	ResponseBody     string   `json:"response_body"`
+	ResponseHeaders  string   `json:"response_headers"`
+	SID              string   `json:"sid"`
+	URI              string   `json:"uri"`
+}
+
+// LogLevel is the severity of a notification
+type LogLevel string
+
+// Log levels
+const (
+	LogError   LogLevel = "0"
+	LogWarning LogLevel = "1"
+)
+
+func (c *Client) notificationsURL() string {
+	return fmt.Sprintf("%s/Notifications.json", c.urlPrefix())
+}
+
+func (c *Client) notificationURL(sid string) string {
+	return fmt.Sprintf("%s/Notifications/%s.json", c.urlPrefix(), sid)
+}
+
+func (c *Client) callNotificationsURL(callSID string) string {
+	return fmt.Sprintf("%s/Calls/%s/Notifications.json", c.urlPrefix(), callSID)
+}
+
+// NotificationListQuery is a struct that contains an embedded
+// utwil.ListQuery. The typing allows the correctly-typed iterator/list to be
+// returned.
+type NotificationListQuery struct {
+	*ListQuery
+	callSID string
+}
+
+// Notifications takes a vargs of utwil.ListQueryConf functions to configure
+// a query for the notifications of the account:
+//
+//	dayAgo := time.Now().AddDate(0, 0, -1)
+//	iter := client.Notifications(
+//		utwil.Log(utwil.LogError),
+//		utwil.LoggedAfterYMD(dayAgo)).Iter()
+//	var n utwil.Notification
+//	for iter.Next(&n) {
+//		fmt.Println(n.ErrorCode, n.RequestURL)
+//	}
+func (c *Client) Notifications(confs ...ListQueryConf) *NotificationListQuery {
+	return &NotificationListQuery{ListQuery: newListQuery(c, confs...)}
+}
+
+// CallNotifications is the same as Client.Notifications, but only queries
+// the notifications of the call with the given SID.
+func (c *Client) CallNotifications(callSID string, confs ...ListQueryConf) *NotificationListQuery {
+	return &NotificationListQuery{ListQuery: newListQuery(c, confs...), callSID: callSID}
+}
+
+// Log filters notifications of the given log level
+func Log(level LogLevel) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("Log", string(level)) }
+}
+
+// LoggedBefore filters notifications logged before a given date string
+// "YYYY-MM-DD"
+func LoggedBefore(ymd string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("MessageDate<", ymd) }
+}
+
+// LoggedBeforeYMD filters notifications logged before a given date (YMD
+// considered only)
+func LoggedBeforeYMD(t time.Time) ListQueryConf {
+	return LoggedBefore(t.Format(YMD))
+}
+
+// LoggedAfter filters notifications logged after a given date string
+// "YYYY-MM-DD"
+func LoggedAfter(ymd string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("MessageDate>", ymd) }
+}
+
+// LoggedAfterYMD filters notifications logged after a given date (YMD
+// considered only)
+func LoggedAfterYMD(t time.Time) ListQueryConf {
+	return LoggedAfter(t.Format(YMD))
+}
+
+// Iter creates an iterator that iterates utwil.Notification results
+func (q *NotificationListQuery) Iter() *NotificationIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as NotificationListQuery.Iter, but every page is
+// fetched with ctx and iteration stops once ctx is done.
+func (q *NotificationListQuery) IterContext(ctx context.Context) *NotificationIter {
+	listURL := q.notificationsURL()
+	if q.callSID != "" {
+		listURL = q.callNotificationsURL(q.callSID)
+	}
+	initURI := fmt.Sprintf("%s?%s", listURL, q.Values.Encode())
+	iter := &NotificationIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &notificationList{}
+	return iter
+}
+
+type notificationList struct {
+	Notifications []Notification `json:"notifications"`
+	listResource
+}
+
+func (nl notificationList) item(idx int) interface{} { return nl.Notifications[idx] }
+func (nl notificationList) size() int                { return len(nl.Notifications) }
+func (nl notificationList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return nl.loadNextPage(ctx, c, &notificationList{})
+}
+
+// GetNotification fetches the notification with the given SID, including
+// the request Twilio made and the response it got. A *NotFoundError is
+// returned if there is no such notification.
+func (c *Client) GetNotification(sid string) (*Notification, error) {
+	return c.GetNotificationContext(context.Background(), sid)
+}
+
+// GetNotificationContext is the same as Client.GetNotification, but the
+// request is bound to ctx.
+func (c *Client) GetNotificationContext(ctx context.Context, sid string) (*Notification, error) {
+	n := &Notification{}
+	if err := c.getJSON(ctx, c.notificationURL(sid), n); err != nil {
+		return nil, notFound(err, "Notification", sid)
+	}
+	return n, nil
+}
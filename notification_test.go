@@ -0,0 +1,56 @@
+package utwil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestNotifications(t *testing.T) {
+	srv := requireFake(t)
+	now := time.Now().UTC()
+	logged := func(level LogLevel, at time.Time) utwiltest.Resource {
+		return utwiltest.Resource{
+			"log":          string(level),
+			"error_code":   "11200",
+			"message_date": at.Format(time.RFC1123Z),
+			"request_url":  "https://example.com/voice",
+		}
+	}
+	srv.Add("Notifications", logged(LogError, now.AddDate(0, 0, -10)))
+	srv.Add("Notifications", logged(LogWarning, now))
+	recent := srv.Add("Notifications", logged(LogError, now))
+	callSID := srv.Add("Calls", utwiltest.Resource{"status": "completed"})["sid"].(string)
+	srv.Add("Calls/"+callSID+"/Notifications", logged(LogWarning, now))
+
+	iter := TestClient.Notifications(Log(LogError), LoggedAfterYMD(now.AddDate(0, 0, -1))).Iter()
+	var n Notification
+	var all []Notification
+	for iter.Next(&n) {
+		all = append(all, n)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if len(all) != 1 || all[0].SID != recent["sid"] || all[0].Log != LogError || all[0].MessageDate == nil {
+		t.Fatalf("unexpected notifications: %+v", all)
+	}
+	req := srv.AssertRequested(t, "GET", "/Notifications.json")
+	if req.Query.Get("Log") != "0" || req.Query.Get("MessageDate>") == "" {
+		t.Fatalf("unexpected query: %v", req.Query)
+	}
+
+	iter = TestClient.CallNotifications(callSID).Iter()
+	if !iter.Next(&n) || n.Log != LogWarning || iter.Next(&n) {
+		t.Fatalf("unexpected call notifications: %v", iter.Err())
+	}
+
+	fetched, err := TestClient.GetNotification(recent["sid"].(string))
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if fetched.RequestURL != "https://example.com/voice" {
+		t.Fatalf("unexpected notification: %+v", fetched)
+	}
+}
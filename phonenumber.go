@@ -0,0 +1,303 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// IncomingPhoneNumber is the Go-representation of Twilio REST API's incoming
+// phone number, a number owned by the account and how it handles calls and
+// messages.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/phone-numbers/api/incomingphonenumber-resource
+type IncomingPhoneNumber struct {
+	AccountSID           string       `json:"account_sid"`
+	AddressRequirements  string       `json:"address_requirements"`
+	AddressSID           string       `json:"address_sid"`
+	APIVersion           string       `json:"api_version"`
+	Beta                 bool         `json:"beta"`
+	BundleSID            string       `json:"bundle_sid"`
+	Capabilities         Capabilities `json:"capabilities"`
+	DateCreated          *Time        `json:"date_created"`
+	DateUpdated          *Time        `json:"date_updated"`
+	EmergencyAddressSID  string       `json:"emergency_address_sid"`
+	EmergencyStatus      string       `json:"emergency_status"`
+	FriendlyName         string       `json:"friendly_name"`
+	IdentitySID          string       `json:"identity_sid"`
+	Origin               string       `json:"origin"`
+	PhoneNumber          string       `json:"phone_number"`
+	SID                  string       `json:"sid"`
+	SMSApplicationSID    string       `json:"sms_application_sid"`
+	SMSFallbackMethod    string       `json:"sms_fallback_method"`
+	SMSFallbackURL       string       `json:"sms_fallback_url"`
+	SMSMethod            string       `json:"sms_method"`
+	SMSURL               string       `json:"sms_url"`
+	Status               string       `json:"status"`
+	StatusCallback       string       `json:"status_callback"`
+	StatusCallbackMethod string       `json:"status_callback_method"`
+	TrunkSID             string       `json:"trunk_sid"`
+	URI                  string       `json:"uri"`
+	VoiceApplicationSID  string       `json:"voice_application_sid"`
+	VoiceCallerIDLookup  bool         `json:"voice_caller_id_lookup"`
+	VoiceFallbackMethod  string       `json:"voice_fallback_method"`
+	VoiceFallbackURL     string       `json:"voice_fallback_url"`
+	VoiceMethod          string       `json:"voice_method"`
+	VoiceReceiveMode     string       `json:"voice_receive_mode"`
+	VoiceURL             string       `json:"voice_url"`
+}
+
+// Capabilities are what a phone number can be used for.
+type Capabilities struct {
+	Voice bool `json:"voice"`
+	SMS   bool `json:"sms"`
+	MMS   bool `json:"mms"`
+	Fax   bool `json:"fax"`
+}
+
+// PhoneNumberType is the type of a phone number, which determines its price
+// and the regulations it is subject to.
+type PhoneNumberType string
+
+// Phone number types
+const (
+	PhoneNumberLocal    PhoneNumberType = "Local"
+	PhoneNumberMobile   PhoneNumberType = "Mobile"
+	PhoneNumberTollFree PhoneNumberType = "TollFree"
+)
+
+func (c *Client) incomingPhoneNumbersURL() string {
+	return fmt.Sprintf("%s/IncomingPhoneNumbers.json", c.urlPrefix())
+}
+
+func (c *Client) incomingPhoneNumberURL(sid string) string {
+	return fmt.Sprintf("%s/IncomingPhoneNumbers/%s.json", c.urlPrefix(), sid)
+}
+
+// IncomingPhoneNumberListQuery is a struct that contains an embedded
+// utwil.ListQuery. The typing allows the correctly-typed iterator/list to be
+// returned.
+type IncomingPhoneNumberListQuery struct {
+	*ListQuery
+	numberType PhoneNumberType
+}
+
+// IncomingPhoneNumbers takes a vargs of utwil.ListQueryConf functions to
+// configure a query for the phone numbers owned by the account:
+//
+//	iter := client.IncomingPhoneNumbers(utwil.FriendlyName("Customer 42")).Iter()
+//	var number utwil.IncomingPhoneNumber
+//	for iter.Next(&number) {
+//		fmt.Println(number.PhoneNumber, number.SMSURL)
+//	}
+func (c *Client) IncomingPhoneNumbers(confs ...ListQueryConf) *IncomingPhoneNumberListQuery {
+	return &IncomingPhoneNumberListQuery{ListQuery: newListQuery(c, confs...)}
+}
+
+// IncomingPhoneNumbersByType is the same as Client.IncomingPhoneNumbers, but
+// only queries the phone numbers of the given type.
+func (c *Client) IncomingPhoneNumbersByType(numberType PhoneNumberType, confs ...ListQueryConf) *IncomingPhoneNumberListQuery {
+	return &IncomingPhoneNumberListQuery{ListQuery: newListQuery(c, confs...), numberType: numberType}
+}
+
+// PhoneNumber filters incoming phone numbers that contain the given digits,
+// e.g. "+1555" for those starting with it.
+func PhoneNumber(number string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("PhoneNumber", number) }
+}
+
+// Iter creates an iterator that iterates utwil.IncomingPhoneNumber results
+func (q *IncomingPhoneNumberListQuery) Iter() *IncomingPhoneNumberIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as IncomingPhoneNumberListQuery.Iter, but every
+// page is fetched with ctx and iteration stops once ctx is done.
+func (q *IncomingPhoneNumberListQuery) IterContext(ctx context.Context) *IncomingPhoneNumberIter {
+	listURL := q.incomingPhoneNumbersURL()
+	if q.numberType != "" {
+		listURL = q.incomingPhoneNumberURL(string(q.numberType))
+	}
+	initURI := fmt.Sprintf("%s?%s", listURL, q.Values.Encode())
+	iter := &IncomingPhoneNumberIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &incomingPhoneNumberList{}
+	return iter
+}
+
+type incomingPhoneNumberList struct {
+	IncomingPhoneNumbers []IncomingPhoneNumber `json:"incoming_phone_numbers"`
+	listResource
+}
+
+func (nl incomingPhoneNumberList) item(idx int) interface{} { return nl.IncomingPhoneNumbers[idx] }
+func (nl incomingPhoneNumberList) size() int                { return len(nl.IncomingPhoneNumbers) }
+func (nl incomingPhoneNumberList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return nl.loadNextPage(ctx, c, &incomingPhoneNumberList{})
+}
+
+// GetIncomingPhoneNumber fetches the phone number with the given SID. A
+// *NotFoundError is returned if the account has no such phone number.
+func (c *Client) GetIncomingPhoneNumber(sid string) (*IncomingPhoneNumber, error) {
+	return c.GetIncomingPhoneNumberContext(context.Background(), sid)
+}
+
+// GetIncomingPhoneNumberContext is the same as Client.GetIncomingPhoneNumber,
+// but the request is bound to ctx.
+func (c *Client) GetIncomingPhoneNumberContext(ctx context.Context, sid string) (*IncomingPhoneNumber, error) {
+	number := &IncomingPhoneNumber{}
+	if err := c.getJSON(ctx, c.incomingPhoneNumberURL(sid), number); err != nil {
+		return nil, notFound(err, "IncomingPhoneNumber", sid)
+	}
+	return number, nil
+}
+
+// PhoneNumberConfig is how a phone number handles calls and messages, as set
+// when purchasing or updating it. Only the settings that are set are sent,
+// so an update leaves the others as they are; String("") clears a setting.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/phone-numbers/api/incomingphonenumber-resource#update-an-incomingphonenumber-resource
+type PhoneNumberConfig struct {
+	FriendlyName         *string
+	VoiceURL             *string
+	VoiceMethod          *string
+	VoiceFallbackURL     *string
+	VoiceFallbackMethod  *string
+	VoiceApplicationSID  *string
+	VoiceCallerIDLookup  *bool
+	VoiceReceiveMode     *string
+	StatusCallback       *string
+	StatusCallbackMethod *string
+	SMSURL               *string
+	SMSMethod            *string
+	SMSFallbackURL       *string
+	SMSFallbackMethod    *string
+	SMSApplicationSID    *string
+	TrunkSID             *string
+	AddressSID           *string
+	IdentitySID          *string
+	EmergencyAddressSID  *string
+	BundleSID            *string
+}
+
+// values populates form fields only if they are set
+func (conf PhoneNumberConfig) values() url.Values {
+	values := url.Values{}
+	for param, value := range map[string]*string{
+		"FriendlyName":         conf.FriendlyName,
+		"VoiceUrl":             conf.VoiceURL,
+		"VoiceMethod":          conf.VoiceMethod,
+		"VoiceFallbackUrl":     conf.VoiceFallbackURL,
+		"VoiceFallbackMethod":  conf.VoiceFallbackMethod,
+		"VoiceApplicationSid":  conf.VoiceApplicationSID,
+		"VoiceReceiveMode":     conf.VoiceReceiveMode,
+		"StatusCallback":       conf.StatusCallback,
+		"StatusCallbackMethod": conf.StatusCallbackMethod,
+		"SmsUrl":               conf.SMSURL,
+		"SmsMethod":            conf.SMSMethod,
+		"SmsFallbackUrl":       conf.SMSFallbackURL,
+		"SmsFallbackMethod":    conf.SMSFallbackMethod,
+		"SmsApplicationSid":    conf.SMSApplicationSID,
+		"TrunkSid":             conf.TrunkSID,
+		"AddressSid":           conf.AddressSID,
+		"IdentitySid":          conf.IdentitySID,
+		"EmergencyAddressSid":  conf.EmergencyAddressSID,
+		"BundleSid":            conf.BundleSID,
+	} {
+		if value != nil {
+			values.Set(param, *value)
+		}
+	}
+	if conf.VoiceCallerIDLookup != nil {
+		values.Set("VoiceCallerIdLookup", strconv.FormatBool(*conf.VoiceCallerIDLookup))
+	}
+	return values
+}
+
+// IncomingPhoneNumberReq is the Go-representation of the Twilio REST API's
+// request to purchase a phone number. Either PhoneNumber, e.g. one found with
+// Client.AvailablePhoneNumbers, or AreaCode must be set.
+type IncomingPhoneNumberReq struct {
+	PhoneNumber string
+	AreaCode    string
+	PhoneNumberConfig
+}
+
+// PurchasePhoneNumber buys a phone number for the account, populating form
+// fields only if they are set.
+//
+// Example:
+//
+//	number, err := client.PurchasePhoneNumber(utwil.IncomingPhoneNumberReq{
+//		PhoneNumber: "+15551231234",
+//		PhoneNumberConfig: utwil.PhoneNumberConfig{
+//			FriendlyName: utwil.String("Customer 42"),
+//			SMSURL:       utwil.String("https://example.com/customers/42/sms"),
+//		},
+//	})
+func (c *Client) PurchasePhoneNumber(req IncomingPhoneNumberReq) (*IncomingPhoneNumber, error) {
+	return c.PurchasePhoneNumberContext(context.Background(), req)
+}
+
+// PurchasePhoneNumberContext is the same as Client.PurchasePhoneNumber, but
+// the request is bound to ctx.
+func (c *Client) PurchasePhoneNumberContext(ctx context.Context, req IncomingPhoneNumberReq) (*IncomingPhoneNumber, error) {
+	if req.PhoneNumber == "" && req.AreaCode == "" {
+		return nil, fmt.Errorf("utwil: PhoneNumber or AreaCode is required")
+	}
+	values := req.values()
+	if req.PhoneNumber != "" {
+		values.Set("PhoneNumber", req.PhoneNumber)
+	}
+	if req.AreaCode != "" {
+		values.Set("AreaCode", req.AreaCode)
+	}
+	number := &IncomingPhoneNumber{}
+	if err := c.postForm(ctx, c.incomingPhoneNumbersURL(), values, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// UpdateIncomingPhoneNumber reconfigures the phone number with the given
+// SID, populating form fields only if they are set, and returns the updated
+// phone number:
+//
+//	// answer calls with TwiML and stop looking up callers' names
+//	number, err := client.UpdateIncomingPhoneNumber(number.SID, utwil.PhoneNumberConfig{
+//		VoiceURL:            utwil.String("https://example.com/voice.twiml"),
+//		VoiceFallbackURL:    utwil.String(""),
+//		VoiceCallerIDLookup: utwil.Bool(false),
+//	})
+func (c *Client) UpdateIncomingPhoneNumber(sid string, conf PhoneNumberConfig) (*IncomingPhoneNumber, error) {
+	return c.UpdateIncomingPhoneNumberContext(context.Background(), sid, conf)
+}
+
+// UpdateIncomingPhoneNumberContext is the same as
+// Client.UpdateIncomingPhoneNumber, but the request is bound to ctx.
+func (c *Client) UpdateIncomingPhoneNumberContext(ctx context.Context, sid string, conf PhoneNumberConfig) (*IncomingPhoneNumber, error) {
+	number := &IncomingPhoneNumber{}
+	if err := c.postForm(ctx, c.incomingPhoneNumberURL(sid), conf.values(), number); err != nil {
+		return nil, notFound(err, "IncomingPhoneNumber", sid)
+	}
+	return number, nil
+}
+
+// ReleasePhoneNumber removes the phone number with the given SID from the
+// account. It stops handling calls and messages immediately and may not be
+// recoverable. A *NotFoundError is returned if the account has no such phone
+// number.
+func (c *Client) ReleasePhoneNumber(sid string) error {
+	return c.ReleasePhoneNumberContext(context.Background(), sid)
+}
+
+// ReleasePhoneNumberContext is the same as Client.ReleasePhoneNumber, but the
+// request is bound to ctx.
+func (c *Client) ReleasePhoneNumberContext(ctx context.Context, sid string) error {
+	err := c.delete(ctx, c.incomingPhoneNumberURL(sid))
+	return notFound(err, "IncomingPhoneNumber", sid)
+}
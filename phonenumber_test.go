@@ -0,0 +1,87 @@
+package utwil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestIncomingPhoneNumbers(t *testing.T) {
+	srv := requireFake(t)
+	srv.Add("IncomingPhoneNumbers", utwiltest.Resource{"phone_number": "+15559870000", "number_type": "Mobile"})
+
+	number, err := TestClient.PurchasePhoneNumber(IncomingPhoneNumberReq{
+		AreaCode: "555",
+		PhoneNumberConfig: PhoneNumberConfig{
+			FriendlyName: String("Customer 42"),
+			SMSURL:       String("https://example.com/customers/42/sms"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if !strings.HasPrefix(number.PhoneNumber, "+1555") || number.FriendlyName != "Customer 42" ||
+		number.SMSURL != "https://example.com/customers/42/sms" || !number.Capabilities.SMS {
+		t.Fatalf("unexpected number: %+v", number)
+	}
+	tollFree, err := TestClient.PurchasePhoneNumber(IncomingPhoneNumberReq{PhoneNumber: "+18005550100"})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, err := TestClient.PurchasePhoneNumber(IncomingPhoneNumberReq{}); err == nil {
+		t.Fatalf("purchase without PhoneNumber or AreaCode was accepted")
+	}
+
+	number, err = TestClient.UpdateIncomingPhoneNumber(number.SID, PhoneNumberConfig{
+		VoiceURL:            String("https://example.com/customers/42/voice"),
+		VoiceCallerIDLookup: Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	req := srv.AssertRequested(t, "POST", "/IncomingPhoneNumbers/"+number.SID+".json")
+	if req.Form.Encode() != "VoiceCallerIdLookup=true&VoiceUrl=https%3A%2F%2Fexample.com%2Fcustomers%2F42%2Fvoice" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+	if !number.VoiceCallerIDLookup || number.SMSURL == "" {
+		t.Fatalf("unexpected number: %+v", number)
+	}
+
+	number, err = TestClient.UpdateIncomingPhoneNumber(number.SID, PhoneNumberConfig{
+		SMSURL:              String(""),
+		VoiceCallerIDLookup: Bool(false),
+	})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	req = srv.AssertRequested(t, "POST", "/IncomingPhoneNumbers/"+number.SID+".json")
+	if req.Form.Encode() != "SmsUrl=&VoiceCallerIdLookup=false" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+	if number.VoiceCallerIDLookup || number.SMSURL != "" || number.VoiceURL == "" {
+		t.Fatalf("unexpected number: %+v", number)
+	}
+
+	for _, test := range []struct {
+		query *IncomingPhoneNumberListQuery
+		sid   string
+	}{
+		{TestClient.IncomingPhoneNumbers(FriendlyName("Customer 42")), number.SID},
+		{TestClient.IncomingPhoneNumbers(PhoneNumber("800555")), tollFree.SID},
+		{TestClient.IncomingPhoneNumbersByType(PhoneNumberTollFree), tollFree.SID},
+	} {
+		iter := test.query.Iter()
+		var listed IncomingPhoneNumber
+		if !iter.Next(&listed) || listed.SID != test.sid || iter.Next(&listed) {
+			t.Fatalf("%v: unexpected numbers: %v", test.query.Values, iter.Err())
+		}
+	}
+
+	if err := TestClient.ReleasePhoneNumber(number.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, err := TestClient.GetIncomingPhoneNumber(number.SID); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Queue is the Go-representation of Twilio REST API's queue, which holds
+// the calls waiting after TwiML's <Enqueue>.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/queue-resource
+type Queue struct {
+	AccountSID      string `json:"account_sid"`
+	AverageWaitTime int    `json:"average_wait_time"`
+	CurrentSize     int    `json:"current_size"`
+	DateCreated     *Time  `json:"date_created"`
+	DateUpdated     *Time  `json:"date_updated"`
+	FriendlyName    string `json:"friendly_name"`
+	MaxSize         int    `json:"max_size"`
+	SID             string `json:"sid"`
+	URI             string `json:"uri"`
+}
+
+// QueueMember is the Go-representation of Twilio REST API's queue member, a
+// call waiting in a queue. WaitTime is in seconds.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/member-resource
+type QueueMember struct {
+	CallSID      string `json:"call_sid"`
+	DateEnqueued *Time  `json:"date_enqueued"`
+	Position     int    `json:"position"`
+	QueueSID     string `json:"queue_sid"`
+	URI          string `json:"uri"`
+	WaitTime     int    `json:"wait_time"`
+}
+
+// FrontMember can be used in place of a call SID to refer to the member at
+// the front of a queue.
+const FrontMember = "Front"
+
+func (c *Client) queuesURL() string {
+	return fmt.Sprintf("%s/Queues.json", c.urlPrefix())
+}
+
+func (c *Client) queueURL(sid string) string {
+	return fmt.Sprintf("%s/Queues/%s.json", c.urlPrefix(), sid)
+}
+
+func (c *Client) queueMembersURL(queueSID string) string {
+	return fmt.Sprintf("%s/Queues/%s/Members.json", c.urlPrefix(), queueSID)
+}
+
+func (c *Client) queueMemberURL(queueSID, callSID string) string {
+	return fmt.Sprintf("%s/Queues/%s/Members/%s.json", c.urlPrefix(), queueSID, callSID)
+}
+
+// QueueListQuery is a struct that contains an embedded utwil.ListQuery.
+// The typing allows the correctly-typed iterator/list to be returned.
+type QueueListQuery struct{ *ListQuery }
+
+// Queues takes a vargs of utwil.ListQueryConf functions to configure the
+// query to be sent to the Twilio API:
+//
+//	iter := client.Queues().Iter()
+//	var queue utwil.Queue
+//	for iter.Next(&queue) {
+//		fmt.Println(queue.FriendlyName, queue.CurrentSize, queue.AverageWaitTime)
+//	}
+func (c *Client) Queues(confs ...ListQueryConf) *QueueListQuery {
+	return &QueueListQuery{ListQuery: newListQuery(c, confs...)}
+}
+
+// Iter creates an iterator that iterates utwil.Queue results
+func (q *QueueListQuery) Iter() *QueueIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as QueueListQuery.Iter, but every page is fetched
+// with ctx and iteration stops once ctx is done.
+func (q *QueueListQuery) IterContext(ctx context.Context) *QueueIter {
+	initURI := fmt.Sprintf("%s?%s", q.queuesURL(), q.Values.Encode())
+	iter := &QueueIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &queueList{}
+	return iter
+}
+
+type queueList struct {
+	Queues []Queue `json:"queues"`
+	listResource
+}
+
+func (ql queueList) item(idx int) interface{} { return ql.Queues[idx] }
+func (ql queueList) size() int                { return len(ql.Queues) }
+func (ql queueList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return ql.loadNextPage(ctx, c, &queueList{})
+}
+
+// CreateQueue creates a queue with the given name, which TwiML's <Enqueue>
+// refers to it by. A maxSize of 0 leaves Twilio's default of 100 calls.
+func (c *Client) CreateQueue(friendlyName string, maxSize int) (*Queue, error) {
+	return c.CreateQueueContext(context.Background(), friendlyName, maxSize)
+}
+
+// CreateQueueContext is the same as Client.CreateQueue, but the request is
+// bound to ctx.
+func (c *Client) CreateQueueContext(ctx context.Context, friendlyName string, maxSize int) (*Queue, error) {
+	values := url.Values{}
+	values.Set("FriendlyName", friendlyName)
+	if maxSize > 0 {
+		values.Set("MaxSize", strconv.Itoa(maxSize))
+	}
+	queue := &Queue{}
+	if err := c.postForm(ctx, c.queuesURL(), values, queue); err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+// GetQueue fetches the queue with the given SID. A *NotFoundError is
+// returned if there is no such queue.
+func (c *Client) GetQueue(sid string) (*Queue, error) {
+	return c.GetQueueContext(context.Background(), sid)
+}
+
+// GetQueueContext is the same as Client.GetQueue, but the request is bound to
+// ctx.
+func (c *Client) GetQueueContext(ctx context.Context, sid string) (*Queue, error) {
+	queue := &Queue{}
+	if err := c.getJSON(ctx, c.queueURL(sid), queue); err != nil {
+		return nil, notFound(err, "Queue", sid)
+	}
+	return queue, nil
+}
+
+// QueueUpdate is the Go-representation of the Twilio REST API's request to
+// modify a queue.
+type QueueUpdate struct {
+	FriendlyName string
+	MaxSize      int
+}
+
+// UpdateQueue modifies the queue with the given SID, populating form fields
+// only if they contain a non-zero value, and returns the updated queue.
+func (c *Client) UpdateQueue(sid string, update QueueUpdate) (*Queue, error) {
+	return c.UpdateQueueContext(context.Background(), sid, update)
+}
+
+// UpdateQueueContext is the same as Client.UpdateQueue, but the request is
+// bound to ctx.
+func (c *Client) UpdateQueueContext(ctx context.Context, sid string, update QueueUpdate) (*Queue, error) {
+	values := url.Values{}
+	if update.FriendlyName != "" {
+		values.Set("FriendlyName", update.FriendlyName)
+	}
+	if update.MaxSize > 0 {
+		values.Set("MaxSize", strconv.Itoa(update.MaxSize))
+	}
+	queue := &Queue{}
+	if err := c.postForm(ctx, c.queueURL(sid), values, queue); err != nil {
+		return nil, notFound(err, "Queue", sid)
+	}
+	return queue, nil
+}
+
+// DeleteQueue deletes the queue with the given SID, which must be empty. A
+// *NotFoundError is returned if there is no such queue.
+func (c *Client) DeleteQueue(sid string) error {
+	return c.DeleteQueueContext(context.Background(), sid)
+}
+
+// DeleteQueueContext is the same as Client.DeleteQueue, but the request is
+// bound to ctx.
+func (c *Client) DeleteQueueContext(ctx context.Context, sid string) error {
+	err := c.delete(ctx, c.queueURL(sid))
+	return notFound(err, "Queue", sid)
+}
+
+// QueueMemberListQuery is a struct that contains an embedded
+// utwil.ListQuery. The typing allows the correctly-typed iterator/list to be
+// returned.
+type QueueMemberListQuery struct {
+	*ListQuery
+	queueSID string
+}
+
+// QueueMembers takes the SID of a queue and a vargs of utwil.ListQueryConf
+// functions to configure a query for the calls waiting in it, front first.
+func (c *Client) QueueMembers(queueSID string, confs ...ListQueryConf) *QueueMemberListQuery {
+	return &QueueMemberListQuery{ListQuery: newListQuery(c, confs...), queueSID: queueSID}
+}
+
+// Iter creates an iterator that iterates utwil.QueueMember results
+func (q *QueueMemberListQuery) Iter() *QueueMemberIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as QueueMemberListQuery.Iter, but every page is
+// fetched with ctx and iteration stops once ctx is done.
+func (q *QueueMemberListQuery) IterContext(ctx context.Context) *QueueMemberIter {
+	initURI := fmt.Sprintf("%s?%s", q.queueMembersURL(q.queueSID), q.Values.Encode())
+	iter := &QueueMemberIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &queueMemberList{}
+	return iter
+}
+
+type queueMemberList struct {
+	Members []QueueMember `json:"queue_members"`
+	listResource
+}
+
+func (ml queueMemberList) item(idx int) interface{} { return ml.Members[idx] }
+func (ml queueMemberList) size() int                { return len(ml.Members) }
+func (ml queueMemberList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return ml.loadNextPage(ctx, c, &queueMemberList{})
+}
+
+// GetQueueMember fetches the member of a queue with the given call SID, or
+// FrontMember. A *NotFoundError is returned if there is no such member.
+func (c *Client) GetQueueMember(queueSID, callSID string) (*QueueMember, error) {
+	return c.GetQueueMemberContext(context.Background(), queueSID, callSID)
+}
+
+// GetQueueMemberContext is the same as Client.GetQueueMember, but the request
+// is bound to ctx.
+func (c *Client) GetQueueMemberContext(ctx context.Context, queueSID, callSID string) (*QueueMember, error) {
+	member := &QueueMember{}
+	if err := c.getJSON(ctx, c.queueMemberURL(queueSID, callSID), member); err != nil {
+		return nil, notFound(err, "QueueMember", callSID)
+	}
+	return member, nil
+}
+
+// DequeueMember takes the call with the given SID, or FrontMember, out of
+// the queue and continues it with the TwiML at twimlURL.
+//
+// Example:
+//
+//	// connect the next caller to an agent
+//	member, err := client.DequeueMember(queue.SID, utwil.FrontMember, "https://example.com/agent.twiml")
+func (c *Client) DequeueMember(queueSID, callSID, twimlURL string) (*QueueMember, error) {
+	return c.DequeueMemberContext(context.Background(), queueSID, callSID, twimlURL)
+}
+
+// DequeueMemberContext is the same as Client.DequeueMember, but the request
+// is bound to ctx.
+func (c *Client) DequeueMemberContext(ctx context.Context, queueSID, callSID, twimlURL string) (*QueueMember, error) {
+	values := url.Values{}
+	values.Set("Url", twimlURL)
+	member := &QueueMember{}
+	if err := c.postForm(ctx, c.queueMemberURL(queueSID, callSID), values, member); err != nil {
+		return nil, notFound(err, "QueueMember", callSID)
+	}
+	return member, nil
+}
+
+// DequeueFront is the same as Client.DequeueMember for the call at the front
+// of the queue.
+func (c *Client) DequeueFront(queueSID, twimlURL string) (*QueueMember, error) {
+	return c.DequeueMemberContext(context.Background(), queueSID, FrontMember, twimlURL)
+}
+
+// DequeueFrontContext is the same as Client.DequeueFront, but the request is
+// bound to ctx.
+func (c *Client) DequeueFrontContext(ctx context.Context, queueSID, twimlURL string) (*QueueMember, error) {
+	return c.DequeueMemberContext(ctx, queueSID, FrontMember, twimlURL)
+}
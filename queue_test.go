@@ -0,0 +1,90 @@
+package utwil
+
+import (
+	"testing"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestQueues(t *testing.T) {
+	srv := requireFake(t)
+	queue, err := TestClient.CreateQueue("support", 0)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if queue.FriendlyName != "support" || queue.MaxSize != 100 {
+		t.Fatalf("unexpected queue: %+v", queue)
+	}
+	if queue, err = TestClient.UpdateQueue(queue.SID, QueueUpdate{MaxSize: 20}); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if req := srv.AssertRequested(t, "POST", "/Queues/"+queue.SID+".json"); req.Form.Encode() != "MaxSize=20" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+
+	iter := TestClient.Queues().Iter()
+	var listed Queue
+	found := false
+	for iter.Next(&listed) {
+		found = found || (listed.SID == queue.SID && listed.MaxSize == 20)
+	}
+	if iter.Err() != nil || !found {
+		t.Fatalf("queue was not listed: %v", iter.Err())
+	}
+
+	if err := TestClient.DeleteQueue(queue.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, err := TestClient.GetQueue(queue.SID); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
+
+func TestQueueMembers(t *testing.T) {
+	srv := requireFake(t)
+	queueSID := srv.Add("Queues", utwiltest.Resource{"friendly_name": "support", "current_size": 3})["sid"].(string)
+	membersPath := "Queues/" + queueSID + "/Members"
+	for i, callSID := range []string{utwiltest.NewSID("CA"), utwiltest.NewSID("CA"), utwiltest.NewSID("CA")} {
+		srv.Add(membersPath, utwiltest.Resource{
+			"call_sid":  callSID,
+			"queue_sid": queueSID,
+			"position":  i + 1,
+			"wait_time": 120 - 60*i,
+		})
+	}
+
+	iter := TestClient.QueueMembers(queueSID).Iter()
+	var member QueueMember
+	var all []QueueMember
+	for iter.Next(&member) {
+		all = append(all, member)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if len(all) != 3 || all[0].Position != 1 || all[0].WaitTime != 120 {
+		t.Fatalf("unexpected members: %+v", all)
+	}
+
+	front, err := TestClient.DequeueFront(queueSID, "https://example.com/agent.twiml")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if front.CallSID != all[0].CallSID {
+		t.Fatalf("dequeued %s instead of the front member %s", front.CallSID, all[0].CallSID)
+	}
+	req := srv.AssertRequested(t, "POST", "/Members/"+FrontMember+".json")
+	if req.Form.Get("Url") != "https://example.com/agent.twiml" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+
+	if _, err := TestClient.DequeueMember(queueSID, all[2].CallSID, "https://example.com/agent.twiml"); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, err := TestClient.GetQueueMember(queueSID, all[2].CallSID); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+	if next, err := TestClient.GetQueueMember(queueSID, FrontMember); err != nil || next.CallSID != all[1].CallSID {
+		t.Fatalf("unexpected front member: %+v, %v", next, err)
+	}
+}
@@ -0,0 +1,147 @@
+package utwil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned instead of blocking by a RateLimiter with NoWait
+// set when a call or message would exceed its budget.
+var ErrRateLimited = errors.New("utwil: rate limit exceeded")
+
+// Rate allows Count requests per Interval, with bursts of up to Count.
+// The zero Rate is unlimited.
+type Rate struct {
+	Count    int
+	Interval time.Duration
+}
+
+// PerSecond returns a Rate of n requests per second, e.g. PerSecond(1) for
+// the throughput of a long code.
+func PerSecond(n int) Rate {
+	return Rate{Count: n, Interval: time.Second}
+}
+
+func (r Rate) unlimited() bool { return r.Count <= 0 || r.Interval <= 0 }
+
+// RateLimiter throttles outbound calls and messages so bulk sends stay within
+// Twilio's throughput limits rather than piling up in its queue. Assign one to
+// Client.RateLimiter; it is safe to share between clients.
+//
+// Example:
+//
+//	client.RateLimiter = &utwil.RateLimiter{
+//		Sender:  utwil.PerSecond(1),
+//		Senders: map[string]utwil.Rate{"+15551231234": utwil.PerSecond(30)},
+//		Account: utwil.PerSecond(100),
+//	}
+type RateLimiter struct {
+	// Account limits all calls and messages sent on behalf of an account
+	Account Rate
+	// Sender limits each From number or messaging service
+	Sender Rate
+	// Senders overrides Sender for specific numbers or service SIDs, e.g.
+	// short codes or toll-free numbers with higher throughput
+	Senders map[string]Rate
+	// NoWait makes an exhausted budget fail with ErrRateLimited instead of
+	// blocking until the request is allowed.
+	NoWait bool
+
+	m       sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket is a token bucket refilled continuously at rate.Count per
+// rate.Interval. Tokens may go negative to queue up blocked callers.
+type bucket struct {
+	rate   Rate
+	tokens float64
+	last   time.Time
+}
+
+func (b *bucket) refill(now time.Time) {
+	perToken := b.rate.Interval / time.Duration(b.rate.Count)
+	b.tokens += float64(now.Sub(b.last)) / float64(perToken)
+	if max := float64(b.rate.Count); b.tokens > max {
+		b.tokens = max
+	}
+	b.last = now
+}
+
+// until returns how long to wait before a token is available
+func (b *bucket) until() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	perToken := b.rate.Interval / time.Duration(b.rate.Count)
+	return time.Duration((1 - b.tokens) * float64(perToken))
+}
+
+func (l *RateLimiter) bucket(key string, rate Rate, now time.Time) *bucket {
+	if l.buckets == nil {
+		l.buckets = make(map[string]*bucket)
+	}
+	b, ok := l.buckets[key]
+	if !ok || b.rate != rate {
+		b = &bucket{rate: rate, tokens: float64(rate.Count), last: now}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// Wait takes one request from the budgets of accountSID and sender, blocking
+// until both allow it or ctx is done. With NoWait set, it returns
+// ErrRateLimited rather than blocking.
+func (l *RateLimiter) Wait(ctx context.Context, accountSID, sender string) error {
+	l.m.Lock()
+	now := time.Now()
+	var buckets []*bucket
+	if !l.Account.unlimited() {
+		buckets = append(buckets, l.bucket("account:"+accountSID, l.Account, now))
+	}
+	rate, ok := l.Senders[sender]
+	if !ok {
+		rate = l.Sender
+	}
+	if !rate.unlimited() && sender != "" {
+		buckets = append(buckets, l.bucket("sender:"+accountSID+":"+sender, rate, now))
+	}
+
+	var wait time.Duration
+	for _, b := range buckets {
+		b.refill(now)
+		if d := b.until(); d > wait {
+			wait = d
+		}
+	}
+	if wait > 0 && l.NoWait {
+		l.m.Unlock()
+		return ErrRateLimited
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	l.m.Unlock()
+
+	if err := sleep(ctx, wait); err != nil {
+		// Give back the tokens this request will never use
+		l.m.Lock()
+		for _, b := range buckets {
+			b.tokens++
+		}
+		l.m.Unlock()
+		return err
+	}
+	return nil
+}
+
+// throttle waits on the client's RateLimiter, if any, before a call or
+// message is sent from sender.
+func (c *Client) throttle(ctx context.Context, sender string) error {
+	if c.RateLimiter == nil {
+		return nil
+	}
+	return c.RateLimiter.Wait(ctx, c.accountSID(), sender)
+}
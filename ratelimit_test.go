@@ -0,0 +1,56 @@
+package utwil
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterNoWait(t *testing.T) {
+	limiter := &RateLimiter{
+		Sender:  PerSecond(1),
+		Senders: map[string]Rate{"+15550000000": PerSecond(2)},
+		Account: PerSecond(4),
+		NoWait:  true,
+	}
+	ctx := context.Background()
+	for _, tc := range []struct {
+		sender string
+		err    error
+	}{
+		{"+15551231234", nil},
+		{"+15551231234", ErrRateLimited},
+		{"+15553214321", nil},
+		{"+15550000000", nil},
+		{"+15550000000", nil},
+		{"+15550000000", ErrRateLimited},
+		{"+15559879876", ErrRateLimited}, // account budget spent
+	} {
+		if err := limiter.Wait(ctx, "AC123", tc.sender); err != tc.err {
+			t.Fatalf("Wait(%s): expected %v, got %v", tc.sender, tc.err, err)
+		}
+	}
+	if err := limiter.Wait(ctx, "AC456", "+15551231234"); err != nil {
+		t.Fatalf("budgets leaked between accounts: %v", err)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := &RateLimiter{Sender: Rate{Count: 1, Interval: 50 * time.Millisecond}}
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, "AC123", "+15551231234"); err != nil {
+			t.Fatalf("Failed: %s", err.Error())
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("3 requests at 1 per 50ms took only %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, "AC123", "+15551231234"); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// Recording is the Go-representation of Twilio REST API's recording of a
+// call or conference.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/recording
+type Recording struct {
+	AccountSID      string `json:"account_sid"`
+	APIVersion      string `json:"api_version"`
+	CallSID         string `json:"call_sid"`
+	Channels        int    `json:"channels"`
+	ConferenceSID   string `json:"conference_sid"`
+	DateCreated     *Time  `json:"date_created"`
+	DateUpdated     *Time  `json:"date_updated"`
+	Duration        string `json:"duration"`
+	ErrorCode       *int   `json:"error_code"`
+	Price           string `json:"price"`
+	PriceUnit       string `json:"price_unit"`
+	SID             string `json:"sid"`
+	Source          string `json:"source"`
+	StartTime       *Time  `json:"start_time"`
+	Status          string `json:"status"`
+	SubresourceURIs struct {
+		AddOnResults   string `json:"add_on_results"`
+		Transcriptions string `json:"transcriptions"`
+	} `json:"subresource_uris"`
+	Track string `json:"track"`
+	URI   string `json:"uri"`
+}
+
+// RecordingStatus is the status of a recording
+type RecordingStatus string
+
+// Recording statuses. Only RecordingInProgress, RecordingPaused and
+// RecordingStopped can be set on a call recording, the others are reported
+// by Twilio as the recording is processed.
+const (
+	RecordingInProgress RecordingStatus = "in-progress"
+	RecordingPaused     RecordingStatus = "paused"
+	RecordingStopped    RecordingStatus = "stopped"
+	RecordingProcessing RecordingStatus = "processing"
+	RecordingCompleted  RecordingStatus = "completed"
+	RecordingAbsent     RecordingStatus = "absent"
+	RecordingDeleted    RecordingStatus = "deleted"
+)
+
+// CurrentRecording can be used in place of a recording SID to refer to the
+// recording currently active on a call.
+const CurrentRecording = "Twilio.CURRENT"
+
+// RecordingFormat is the audio format a recording is downloaded in
+type RecordingFormat string
+
+// Recording formats
+const (
+	RecordingWAV RecordingFormat = "wav"
+	RecordingMP3 RecordingFormat = "mp3"
+)
+
+func (c *Client) recordingsURL() string {
+	return fmt.Sprintf("%s/Recordings.json", c.urlPrefix())
+}
+
+// recordingURL is the URL of the recording's audio; its metadata is at
+// ".json"
+func (c *Client) recordingURL(sid string) string {
+	return fmt.Sprintf("%s/Recordings/%s", c.urlPrefix(), sid)
+}
+
+func (c *Client) callRecordingsURL(callSID string) string {
+	return fmt.Sprintf("%s/Calls/%s/Recordings.json", c.urlPrefix(), callSID)
+}
+
+func (c *Client) callRecordingURL(callSID, sid string) string {
+	return fmt.Sprintf("%s/Calls/%s/Recordings/%s.json", c.urlPrefix(), callSID, sid)
+}
+
+// RecordingListQuery is a struct that contains an embedded utwil.ListQuery.
+// The typing allows the correctly-typed iterator/list to be returned.
+type RecordingListQuery struct {
+	*ListQuery
+	callSID string
+}
+
+// Recordings takes a vargs of utwil.ListQueryConf functions to configure a
+// query for all recordings of the account:
+//
+//	weekAgo := time.Now().AddDate(0, 0, -7)
+//	iter := client.Recordings(utwil.CreatedAfterYMD(weekAgo)).Iter()
+//	var rec utwil.Recording
+//	for iter.Next(&rec) {
+//		// use rec
+//	}
+func (c *Client) Recordings(confs ...ListQueryConf) *RecordingListQuery {
+	return &RecordingListQuery{ListQuery: newListQuery(c, confs...)}
+}
+
+// CallRecordings is the same as Client.Recordings, but only queries the
+// recordings of the call with the given SID.
+func (c *Client) CallRecordings(callSID string, confs ...ListQueryConf) *RecordingListQuery {
+	return &RecordingListQuery{ListQuery: newListQuery(c, confs...), callSID: callSID}
+}
+
+// CreatedBefore filters resources created before a given date string
+// "YYYY-MM-DD"
+func CreatedBefore(ymd string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("DateCreated<", ymd) }
+}
+
+// CreatedBeforeYMD filters resources created before a given date (YMD
+// considered only)
+func CreatedBeforeYMD(t time.Time) ListQueryConf {
+	return CreatedBefore(t.Format(YMD))
+}
+
+// CreatedAfter filters resources created after a given date string
+// "YYYY-MM-DD"
+func CreatedAfter(ymd string) ListQueryConf {
+	return func(q *ListQuery) { q.Values.Set("DateCreated>", ymd) }
+}
+
+// CreatedAfterYMD filters resources created after a given date (YMD
+// considered only)
+func CreatedAfterYMD(t time.Time) ListQueryConf {
+	return CreatedAfter(t.Format(YMD))
+}
+
+// Iter creates an iterator that iterates utwil.Recording results
+func (q *RecordingListQuery) Iter() *RecordingIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as RecordingListQuery.Iter, but every page is
+// fetched with ctx and iteration stops once ctx is done.
+func (q *RecordingListQuery) IterContext(ctx context.Context) *RecordingIter {
+	listURL := q.recordingsURL()
+	if q.callSID != "" {
+		listURL = q.callRecordingsURL(q.callSID)
+	}
+	initURI := fmt.Sprintf("%s?%s", listURL, q.Values.Encode())
+	iter := &RecordingIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &recordingList{}
+	return iter
+}
+
+type recordingList struct {
+	Recordings []Recording `json:"recordings"`
+	listResource
+}
+
+func (rl recordingList) item(idx int) interface{} { return rl.Recordings[idx] }
+func (rl recordingList) size() int                { return len(rl.Recordings) }
+func (rl recordingList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return rl.loadNextPage(ctx, c, &recordingList{})
+}
+
+// GetRecording fetches the recording with the given SID. A *NotFoundError is
+// returned if there is no such recording.
+func (c *Client) GetRecording(sid string) (*Recording, error) {
+	return c.GetRecordingContext(context.Background(), sid)
+}
+
+// GetRecordingContext is the same as Client.GetRecording, but the request is
+// bound to ctx.
+func (c *Client) GetRecordingContext(ctx context.Context, sid string) (*Recording, error) {
+	rec := &Recording{}
+	if err := c.getJSON(ctx, c.recordingURL(sid)+".json", rec); err != nil {
+		return nil, notFound(err, "Recording", sid)
+	}
+	return rec, nil
+}
+
+// DeleteRecording deletes the recording with the given SID. A
+// *NotFoundError is returned if there is no such recording.
+func (c *Client) DeleteRecording(sid string) error {
+	return c.DeleteRecordingContext(context.Background(), sid)
+}
+
+// DeleteRecordingContext is the same as Client.DeleteRecording, but the
+// request is bound to ctx.
+func (c *Client) DeleteRecordingContext(ctx context.Context, sid string) error {
+	err := c.delete(ctx, c.recordingURL(sid)+".json")
+	return notFound(err, "Recording", sid)
+}
+
+// DownloadRecording streams the audio of a recording to w in the given
+// format and returns its content type:
+//
+//	f, err := os.Create(rec.SID + ".mp3")
+//	// handle err
+//	defer f.Close()
+//	_, err = client.DownloadRecording(rec.SID, utwil.RecordingMP3, f)
+func (c *Client) DownloadRecording(sid string, format RecordingFormat, w io.Writer) (string, error) {
+	return c.DownloadRecordingContext(context.Background(), sid, format, w)
+}
+
+// DownloadRecordingContext is the same as Client.DownloadRecording, but the
+// request is bound to ctx.
+func (c *Client) DownloadRecordingContext(ctx context.Context, sid string, format RecordingFormat, w io.Writer) (string, error) {
+	return c.download(ctx, c.recordingURL(sid)+"."+string(format), w, "Recording", sid)
+}
+
+// CallRecordingReq is the Go-representation of the Twilio REST API's request
+// to start recording a call in progress.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/recording#create-a-recording-resource
+type CallRecordingReq struct {
+	RecordingStatusCallback       string
+	RecordingStatusCallbackMethod string
+	RecordingStatusCallbackEvent  []string
+	RecordingChannels             string
+	RecordingTrack                string
+	Trim                          string
+}
+
+// StartCallRecording starts recording the call with the given SID,
+// populating form fields only if they contain a non-zero value.
+func (c *Client) StartCallRecording(callSID string, req CallRecordingReq) (*Recording, error) {
+	return c.StartCallRecordingContext(context.Background(), callSID, req)
+}
+
+// StartCallRecordingContext is the same as Client.StartCallRecording, but the
+// request is bound to ctx.
+func (c *Client) StartCallRecordingContext(ctx context.Context, callSID string, req CallRecordingReq) (*Recording, error) {
+	values := url.Values{}
+	if req.RecordingStatusCallback != "" {
+		values.Set("RecordingStatusCallback", req.RecordingStatusCallback)
+	}
+	if req.RecordingStatusCallbackMethod != "" {
+		values.Set("RecordingStatusCallbackMethod", req.RecordingStatusCallbackMethod)
+	}
+	for _, event := range req.RecordingStatusCallbackEvent {
+		values.Add("RecordingStatusCallbackEvent", event)
+	}
+	if req.RecordingChannels != "" {
+		values.Set("RecordingChannels", req.RecordingChannels)
+	}
+	if req.RecordingTrack != "" {
+		values.Set("RecordingTrack", req.RecordingTrack)
+	}
+	if req.Trim != "" {
+		values.Set("Trim", req.Trim)
+	}
+	rec := &Recording{}
+	if err := c.postForm(ctx, c.callRecordingsURL(callSID), values, rec); err != nil {
+		return nil, notFound(err, "Call", callSID)
+	}
+	return rec, nil
+}
+
+// updateCallRecording sets the status of a call's recording, which may be
+// CurrentRecording
+func (c *Client) updateCallRecording(ctx context.Context, callSID, sid string, values url.Values) (*Recording, error) {
+	rec := &Recording{}
+	if err := c.postForm(ctx, c.callRecordingURL(callSID, sid), values, rec); err != nil {
+		return nil, notFound(err, "Recording", sid)
+	}
+	return rec, nil
+}
+
+// PauseCallRecording pauses a recording of a call in progress. pauseBehavior
+// is "skip" to leave the paused time out of the recording or "silence" to
+// fill it with silence; Twilio defaults to "silence" if it is empty.
+//
+// Example:
+//
+//	// don't record the card number
+//	rec, err := client.PauseCallRecording(call.SID, utwil.CurrentRecording, "skip")
+func (c *Client) PauseCallRecording(callSID, sid, pauseBehavior string) (*Recording, error) {
+	return c.PauseCallRecordingContext(context.Background(), callSID, sid, pauseBehavior)
+}
+
+// PauseCallRecordingContext is the same as Client.PauseCallRecording, but the
+// request is bound to ctx.
+func (c *Client) PauseCallRecordingContext(ctx context.Context, callSID, sid, pauseBehavior string) (*Recording, error) {
+	values := url.Values{"Status": {string(RecordingPaused)}}
+	if pauseBehavior != "" {
+		values.Set("PauseBehavior", pauseBehavior)
+	}
+	return c.updateCallRecording(ctx, callSID, sid, values)
+}
+
+// ResumeCallRecording resumes a paused recording of a call in progress.
+func (c *Client) ResumeCallRecording(callSID, sid string) (*Recording, error) {
+	return c.ResumeCallRecordingContext(context.Background(), callSID, sid)
+}
+
+// ResumeCallRecordingContext is the same as Client.ResumeCallRecording, but
+// the request is bound to ctx.
+func (c *Client) ResumeCallRecordingContext(ctx context.Context, callSID, sid string) (*Recording, error) {
+	values := url.Values{"Status": {string(RecordingInProgress)}}
+	return c.updateCallRecording(ctx, callSID, sid, values)
+}
+
+// StopCallRecording stops a recording of a call in progress. The call goes on
+// and the recording becomes available once Twilio has processed it.
+func (c *Client) StopCallRecording(callSID, sid string) (*Recording, error) {
+	return c.StopCallRecordingContext(context.Background(), callSID, sid)
+}
+
+// StopCallRecordingContext is the same as Client.StopCallRecording, but the
+// request is bound to ctx.
+func (c *Client) StopCallRecordingContext(ctx context.Context, callSID, sid string) (*Recording, error) {
+	values := url.Values{"Status": {string(RecordingStopped)}}
+	return c.updateCallRecording(ctx, callSID, sid, values)
+}
@@ -0,0 +1,99 @@
+package utwil
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestRecordings(t *testing.T) {
+	srv := requireFake(t)
+	old := srv.Add("Recordings", utwiltest.Resource{
+		"date_created": time.Now().AddDate(0, 0, -30).UTC().Format(time.RFC1123Z),
+	})
+	recent := srv.Add("Recordings", utwiltest.Resource{"status": "completed", "duration": "42"})
+	srv.SetContent("Recordings/"+recent["sid"].(string)+".mp3", "audio/mpeg", []byte("ID3"))
+
+	weekAgo := time.Now().AddDate(0, 0, -7)
+	iter := TestClient.Recordings(CreatedAfterYMD(weekAgo)).Iter()
+	var rec Recording
+	var all []Recording
+	for iter.Next(&rec) {
+		all = append(all, rec)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if len(all) != 1 || all[0].SID != recent["sid"] || all[0].Duration != "42" {
+		t.Fatalf("unexpected recordings: %+v", all)
+	}
+
+	var buf bytes.Buffer
+	contentType, err := TestClient.DownloadRecording(all[0].SID, RecordingMP3, &buf)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if contentType != "audio/mpeg" || buf.String() != "ID3" {
+		t.Fatalf("unexpected content: %s %q", contentType, buf.String())
+	}
+
+	if err := TestClient.DeleteRecording(old["sid"].(string)); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, err := TestClient.GetRecording(old["sid"].(string)); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
+
+func TestCallRecording(t *testing.T) {
+	srv := requireFake(t)
+	callSID := srv.Add("Calls", utwiltest.Resource{"status": "in-progress"})["sid"].(string)
+
+	rec, err := TestClient.StartCallRecording(callSID, CallRecordingReq{
+		RecordingChannels:            "dual",
+		RecordingStatusCallbackEvent: []string{"in-progress", "completed"},
+	})
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if rec.CallSID != callSID || RecordingStatus(rec.Status) != RecordingInProgress || rec.Channels != 2 {
+		t.Fatalf("unexpected recording: %+v", rec)
+	}
+	form := srv.AssertRequested(t, "POST", "/Calls/"+callSID+"/Recordings.json").Form
+	if len(form["RecordingStatusCallbackEvent"]) != 2 {
+		t.Fatalf("unexpected form: %v", form)
+	}
+
+	rec, err = TestClient.PauseCallRecording(callSID, CurrentRecording, "skip")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	req := srv.AssertRequested(t, "POST", "/Recordings/"+CurrentRecording+".json")
+	if req.Form.Get("Status") != "paused" || req.Form.Get("PauseBehavior") != "skip" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+	if rec, err = TestClient.ResumeCallRecording(callSID, rec.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if rec, err = TestClient.StopCallRecording(callSID, rec.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if RecordingStatus(rec.Status) != RecordingStopped {
+		t.Fatalf("unexpected recording: %+v", rec)
+	}
+
+	iter := TestClient.CallRecordings(callSID).Iter()
+	var listed Recording
+	if !iter.Next(&listed) || listed.SID != rec.SID {
+		t.Fatalf("recording not listed under call: %v", iter.Err())
+	}
+	if fetched, err := TestClient.GetRecording(rec.SID); err != nil || fetched.CallSID != callSID {
+		t.Fatalf("recording not found under account: %v", err)
+	}
+
+	if _, err := TestClient.StopCallRecording(callSID, CurrentRecording); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
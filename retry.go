@@ -0,0 +1,150 @@
+package utwil
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// IdempotencyTokenHeader is the header that carries the key set with
+// WithIdempotencyKey. Twilio sets it on the webhook requests it sends, but it
+// does not deduplicate REST API requests on it.
+const IdempotencyTokenHeader = "I-Twilio-Idempotency-Token"
+
+// RetryPolicy decides whether a failed request is attempted again.
+//
+// Retry is called after every failed attempt, counting from 1, with either
+// the non-2xx response or the transport error. It returns how long to wait
+// before the next attempt, or false to give up and surface the failure.
+type RetryPolicy interface {
+	Retry(attempt int, req *http.Request, resp *http.Response, err error) (time.Duration, bool)
+}
+
+// Backoff is a RetryPolicy that retries 429 and 5xx responses and transport
+// errors with jittered exponential backoff, honouring Retry-After when Twilio
+// sends one.
+//
+// Only GET and HEAD requests are retried, unless RetryKeyedPOSTs is set and
+// the request carries an idempotency token (see WithIdempotencyKey). Retried
+// POSTs are at-least-once: a POST that timed out or failed with a 5xx may
+// have taken effect nonetheless, and is then duplicated. Waits, including
+// Retry-After, are capped at MaxDelay when it is set.
+type Backoff struct {
+	MaxRetries      int
+	MinDelay        time.Duration
+	MaxDelay        time.Duration
+	RetryKeyedPOSTs bool
+}
+
+// DefaultRetryPolicy returns the policy installed by NewClient, which only
+// retries GET and HEAD requests.
+func DefaultRetryPolicy() *Backoff {
+	return &Backoff{
+		MaxRetries: 3,
+		MinDelay:   500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// Retry implements utwil.RetryPolicy
+func (b *Backoff) Retry(attempt int, req *http.Request, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt > b.MaxRetries || !retryable(req, resp, err, b.RetryKeyedPOSTs) {
+		return 0, false
+	}
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			if b.MaxDelay > 0 && wait > b.MaxDelay {
+				wait = b.MaxDelay
+			}
+			return wait, true
+		}
+	}
+
+	delay := b.MinDelay << uint(attempt-1)
+	if delay <= 0 || (b.MaxDelay > 0 && delay > b.MaxDelay) {
+		delay = b.MaxDelay
+	}
+	// "Equal jitter": wait at least half the delay
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay, true
+	}
+	return time.Duration(half + rand.Int63n(half+1)), true
+}
+
+// retryable reports whether a failed attempt is safe and worth retrying
+func retryable(req *http.Request, resp *http.Response, err error, keyedPOSTs bool) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	switch req.Method {
+	case "GET", "HEAD":
+	default:
+		if !keyedPOSTs || req.Header.Get(IdempotencyTokenHeader) == "" {
+			return false
+		}
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// retryAfter parses the Retry-After header in either of its two forms
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(t); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx that makes POSTs sent with it
+// carry key as their idempotency token, which opts them in to being retried
+// by a Backoff with RetryKeyedPOSTs set:
+//
+//	client.RetryPolicy = &utwil.Backoff{MaxRetries: 3, MinDelay: time.Second, RetryKeyedPOSTs: true}
+//	ctx := utwil.WithIdempotencyKey(context.Background(), reminder.ID)
+//	msg, err := client.SendSMSContext(ctx, from, to, reminder.Text)
+//
+// The key does not prevent duplicates. Twilio does not deduplicate requests
+// on it, so a retry after a timeout or a 5xx can send the message or place
+// the call twice. Only opt in where a duplicate is preferable to a lost
+// request.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+func idempotencyKeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+// sleep waits for d, returning early with the context's error if it is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package utwil
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Transcription is the Go-representation of Twilio REST API's
+// transcription of a recording.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/recording-transcription
+type Transcription struct {
+	AccountSID        string `json:"account_sid"`
+	APIVersion        string `json:"api_version"`
+	DateCreated       *Time  `json:"date_created"`
+	DateUpdated       *Time  `json:"date_updated"`
+	Duration          string `json:"duration"`
+	Price             string `json:"price"`
+	PriceUnit         string `json:"price_unit"`
+	RecordingSID      string `json:"recording_sid"`
+	SID               string `json:"sid"`
+	Status            string `json:"status"`
+	TranscriptionText string `json:"transcription_text"`
+	Type              string `json:"type"`
+	URI               string `json:"uri"`
+}
+
+func (c *Client) transcriptionsURL() string {
+	return fmt.Sprintf("%s/Transcriptions.json", c.urlPrefix())
+}
+
+func (c *Client) transcriptionURL(sid string) string {
+	return fmt.Sprintf("%s/Transcriptions/%s.json", c.urlPrefix(), sid)
+}
+
+func (c *Client) recordingTranscriptionsURL(recordingSID string) string {
+	return fmt.Sprintf("%s/Recordings/%s/Transcriptions.json", c.urlPrefix(), recordingSID)
+}
+
+// TranscriptionListQuery is a struct that contains an embedded
+// utwil.ListQuery. The typing allows the correctly-typed iterator/list to be
+// returned.
+type TranscriptionListQuery struct {
+	*ListQuery
+	recordingSID string
+}
+
+// Transcriptions takes a vargs of utwil.ListQueryConf functions to configure
+// a query for all transcriptions of the account, newest first. Twilio does
+// not filter transcriptions, so utwil.CreatedBefore and utwil.CreatedAfter
+// are applied by the TranscriptionIter instead, which stops at the first
+// transcription older than the CreatedAfter day:
+//
+//	dayAgo := time.Now().AddDate(0, 0, -1)
+//	iter := client.Transcriptions(utwil.CreatedAfterYMD(dayAgo)).Iter()
+//	var tr utwil.Transcription
+//	for iter.Next(&tr) {
+//		fmt.Println(tr.RecordingSID, tr.TranscriptionText)
+//	}
+func (c *Client) Transcriptions(confs ...ListQueryConf) *TranscriptionListQuery {
+	return &TranscriptionListQuery{ListQuery: newListQuery(c, confs...)}
+}
+
+// RecordingTranscriptions is the same as Client.Transcriptions, but only
+// queries the transcriptions of the recording with the given SID.
+func (c *Client) RecordingTranscriptions(recordingSID string, confs ...ListQueryConf) *TranscriptionListQuery {
+	return &TranscriptionListQuery{ListQuery: newListQuery(c, confs...), recordingSID: recordingSID}
+}
+
+// Iter creates an iterator that iterates utwil.Transcription results
+func (q *TranscriptionListQuery) Iter() *TranscriptionIter {
+	return q.IterContext(context.Background())
+}
+
+// IterContext is the same as TranscriptionListQuery.Iter, but every page is
+// fetched with ctx and iteration stops once ctx is done.
+func (q *TranscriptionListQuery) IterContext(ctx context.Context) *TranscriptionIter {
+	listURL := q.transcriptionsURL()
+	if q.recordingSID != "" {
+		listURL = q.recordingTranscriptionsURL(q.recordingSID)
+	}
+	values := url.Values{}
+	for k, v := range q.Values {
+		values[k] = v
+	}
+	before, after := values.Get("DateCreated<"), values.Get("DateCreated>")
+	values.Del("DateCreated<")
+	values.Del("DateCreated>")
+
+	initURI := fmt.Sprintf("%s?%s", listURL, values.Encode())
+	iter := &TranscriptionIter{iter: newIter(ctx, q.Client, initURI)}
+	iter.iterable = &transcriptionList{}
+	var err error
+	if iter.before, err = parseDateBound(before); err != nil {
+		iter.err = err
+	} else if iter.after, err = parseDateBound(after); err != nil {
+		iter.err = err
+	}
+	if !iter.before.IsZero() {
+		// CreatedBefore includes the given day
+		iter.before = iter.before.AddDate(0, 0, 1)
+	}
+	return iter
+}
+
+// parseDateBound parses the YMD date of a CreatedBefore or CreatedAfter
+// filter, returning the zero time for an unset filter
+func parseDateBound(ymd string) (time.Time, error) {
+	if ymd == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(YMD, ymd)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("utwil: invalid date filter %q: %w", ymd, err)
+	}
+	return t, nil
+}
+
+type transcriptionList struct {
+	Transcriptions []Transcription `json:"transcriptions"`
+	listResource
+}
+
+func (tl transcriptionList) item(idx int) interface{} { return tl.Transcriptions[idx] }
+func (tl transcriptionList) size() int                { return len(tl.Transcriptions) }
+func (tl transcriptionList) nextPage(ctx context.Context, c *Client) (iterable, error) {
+	return tl.loadNextPage(ctx, c, &transcriptionList{})
+}
+
+// GetTranscription fetches the transcription with the given SID, including
+// its text. A *NotFoundError is returned if there is no such transcription.
+func (c *Client) GetTranscription(sid string) (*Transcription, error) {
+	return c.GetTranscriptionContext(context.Background(), sid)
+}
+
+// GetTranscriptionContext is the same as Client.GetTranscription, but the
+// request is bound to ctx.
+func (c *Client) GetTranscriptionContext(ctx context.Context, sid string) (*Transcription, error) {
+	tr := &Transcription{}
+	if err := c.getJSON(ctx, c.transcriptionURL(sid), tr); err != nil {
+		return nil, notFound(err, "Transcription", sid)
+	}
+	return tr, nil
+}
+
+// DeleteTranscription deletes the transcription with the given SID. A
+// *NotFoundError is returned if there is no such transcription.
+func (c *Client) DeleteTranscription(sid string) error {
+	return c.DeleteTranscriptionContext(context.Background(), sid)
+}
+
+// DeleteTranscriptionContext is the same as Client.DeleteTranscription, but
+// the request is bound to ctx.
+func (c *Client) DeleteTranscriptionContext(ctx context.Context, sid string) error {
+	err := c.delete(ctx, c.transcriptionURL(sid))
+	return notFound(err, "Transcription", sid)
+}
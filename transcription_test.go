@@ -0,0 +1,59 @@
+package utwil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func TestTranscriptions(t *testing.T) {
+	srv := requireFake(t)
+	recSID := srv.Add("Recordings", utwiltest.Resource{"status": "completed"})["sid"].(string)
+	old := srv.Add("Transcriptions", utwiltest.Resource{
+		"date_created": time.Now().AddDate(0, 0, -30).UTC().Format(time.RFC1123Z),
+	})
+	recent := srv.Add("Transcriptions", utwiltest.Resource{
+		"recording_sid":      recSID,
+		"status":             "completed",
+		"transcription_text": "Hi, please call me back.",
+	})
+	srv.Add("Recordings/"+recSID+"/Transcriptions", recent)
+
+	iter := TestClient.Transcriptions(CreatedAfterYMD(time.Now().AddDate(0, 0, -1))).Iter()
+	var tr Transcription
+	var all []Transcription
+	for iter.Next(&tr) {
+		all = append(all, tr)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if len(all) != 1 || all[0].SID != recent["sid"] {
+		t.Fatalf("unexpected transcriptions: %+v", all)
+	}
+
+	iter = TestClient.Transcriptions(CreatedBeforeYMD(time.Now().AddDate(0, 0, -2))).Iter()
+	if !iter.Next(&tr) || tr.SID != old["sid"] || iter.Next(&tr) {
+		t.Fatalf("unexpected transcriptions created before: %v", iter.Err())
+	}
+
+	iter = TestClient.RecordingTranscriptions(recSID).Iter()
+	if !iter.Next(&tr) || tr.RecordingSID != recSID || iter.Next(&tr) {
+		t.Fatalf("unexpected recording transcriptions: %v", iter.Err())
+	}
+
+	fetched, err := TestClient.GetTranscription(tr.SID)
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if fetched.TranscriptionText != "Hi, please call me back." {
+		t.Fatalf("unexpected transcription: %+v", fetched)
+	}
+	if err := TestClient.DeleteTranscription(tr.SID); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if _, err := TestClient.GetTranscription(tr.SID); !IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got: %v", err)
+	}
+}
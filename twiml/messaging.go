@@ -0,0 +1,72 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// MessagingVerb is a verb of a MessagingResponse: *Message or *Redirect.
+type MessagingVerb interface{ messagingVerb() }
+
+// MessagingResponse is the <Response> to a messaging webhook request, which
+// lets a handler reply to an inbound message without a separate REST call.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/messaging/twiml
+type MessagingResponse struct {
+	XMLName xml.Name `xml:"Response"`
+	Verbs   []MessagingVerb
+}
+
+// NewMessagingResponse creates a MessagingResponse executing verbs in order.
+//
+// Example:
+//
+//	twiml.NewMessagingResponse(&twiml.Message{
+//		Body:      "Thanks! Here is your receipt.",
+//		MediaURLs: []string{"https://example.com/receipt.png"},
+//	}).Write(w)
+func NewMessagingResponse(verbs ...MessagingVerb) *MessagingResponse {
+	return &MessagingResponse{Verbs: verbs}
+}
+
+// Add appends verbs to the response and returns it, for chaining.
+func (r *MessagingResponse) Add(verbs ...MessagingVerb) *MessagingResponse {
+	r.Verbs = append(r.Verbs, verbs...)
+	return r
+}
+
+// Marshal renders the response as a TwiML document.
+func (r *MessagingResponse) Marshal() ([]byte, error) { return marshal(r) }
+
+// String renders the response, or returns "" if it cannot be rendered.
+func (r *MessagingResponse) String() string {
+	doc, _ := r.Marshal()
+	return string(doc)
+}
+
+// Write serves the response as TwiML on w.
+func (r *MessagingResponse) Write(w http.ResponseWriter) error { return write(w, r) }
+
+// ServeHTTP serves the same response to every request.
+func (r *MessagingResponse) ServeHTTP(w http.ResponseWriter, _ *http.Request) { r.Write(w) }
+
+// Message sends a message, by default back to the sender of the inbound
+// message from the number it was sent to. Its fields mirror those of
+// utwil.MessageReq, with Action taking the place of StatusCallback.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/messaging/twiml/message
+type Message struct {
+	XMLName   xml.Name `xml:"Message"`
+	From      string   `xml:"from,attr,omitempty"`
+	To        string   `xml:"to,attr,omitempty"`
+	Action    string   `xml:"action,attr,omitempty"`
+	Method    string   `xml:"method,attr,omitempty"`
+	Body      string   `xml:"Body,omitempty"`
+	MediaURLs []string `xml:"Media"`
+}
+
+func (*Message) messagingVerb() {}
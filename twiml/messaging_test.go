@@ -0,0 +1,40 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMessagingResponse(t *testing.T) {
+	resp := NewMessagingResponse(
+		&Message{Body: "Thanks & goodbye"},
+		&Message{
+			To:        "+15553214321",
+			From:      "+15551231234",
+			Action:    "/status",
+			Method:    "POST",
+			Body:      "Your receipt",
+			MediaURLs: []string{"https://example.com/1.png", "https://example.com/2.png"},
+		},
+	).Add(&Redirect{URL: "/next"})
+
+	expected := xml.Header + `<Response>` +
+		`<Message><Body>Thanks &amp; goodbye</Body></Message>` +
+		`<Message from="+15551231234" to="+15553214321" action="/status" method="POST">` +
+		`<Body>Your receipt</Body><Media>https://example.com/1.png</Media><Media>https://example.com/2.png</Media>` +
+		`</Message>` +
+		`<Redirect>/next</Redirect>` +
+		`</Response>`
+	if resp.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, resp.String())
+	}
+
+	w := httptest.NewRecorder()
+	if err := resp.Write(w); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if w.Header().Get("Content-Type") != ContentType || w.Body.String() != expected {
+		t.Fatalf("unexpected response: %s %s", w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
@@ -0,0 +1,63 @@
+// Package twiml builds TwiML, the XML documents Twilio requests from the
+// URLs of calls and messages to learn what to do next.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml
+//	https://www.twilio.com/docs/messaging/twiml
+//
+// Responses are built from typed verbs and can be served directly:
+//
+//	func callHandler(w http.ResponseWriter, r *http.Request) {
+//		resp := twiml.NewVoiceResponse(
+//			&twiml.Say{Text: "Connecting you now.", Voice: "alice"},
+//			&twiml.Dial{Nouns: []twiml.DialNoun{&twiml.Number{PhoneNumber: "+15559871234"}}},
+//		)
+//		resp.Write(w)
+//	}
+package twiml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+)
+
+// ContentType is the content type TwiML is served with
+const ContentType = "text/xml; charset=utf-8"
+
+// Bool returns a pointer to b, for attributes that default to true in
+// Twilio and are therefore only rendered when set.
+func Bool(b bool) *bool { return &b }
+
+// marshal renders a response as a TwiML document
+func marshal(v interface{}) ([]byte, error) {
+	buf := bytes.NewBufferString(xml.Header)
+	if err := xml.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// write serves a response as a TwiML document
+func write(w http.ResponseWriter, v interface{}) error {
+	doc, err := marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Set("Content-Type", ContentType)
+	_, err = w.Write(doc)
+	return err
+}
+
+// Redirect transfers control to the TwiML at another URL. It is valid in
+// both voice and messaging responses.
+type Redirect struct {
+	XMLName xml.Name `xml:"Redirect"`
+	URL     string   `xml:",chardata"`
+	Method  string   `xml:"method,attr,omitempty"`
+}
+
+func (*Redirect) voiceVerb()     {}
+func (*Redirect) messagingVerb() {}
@@ -0,0 +1,314 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"net/http"
+)
+
+// VoiceVerb is a verb of a VoiceResponse, e.g. *Say or *Dial.
+type VoiceVerb interface{ voiceVerb() }
+
+// VoiceResponse is the <Response> to a voice webhook request.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml
+type VoiceResponse struct {
+	XMLName xml.Name `xml:"Response"`
+	Verbs   []VoiceVerb
+}
+
+// NewVoiceResponse creates a VoiceResponse executing verbs in order.
+func NewVoiceResponse(verbs ...VoiceVerb) *VoiceResponse {
+	return &VoiceResponse{Verbs: verbs}
+}
+
+// Add appends verbs to the response and returns it, for chaining.
+func (r *VoiceResponse) Add(verbs ...VoiceVerb) *VoiceResponse {
+	r.Verbs = append(r.Verbs, verbs...)
+	return r
+}
+
+// Marshal renders the response as a TwiML document.
+func (r *VoiceResponse) Marshal() ([]byte, error) { return marshal(r) }
+
+// String renders the response, or returns "" if it cannot be rendered.
+func (r *VoiceResponse) String() string {
+	doc, _ := r.Marshal()
+	return string(doc)
+}
+
+// Write serves the response as TwiML on w.
+func (r *VoiceResponse) Write(w http.ResponseWriter) error { return write(w, r) }
+
+// ServeHTTP serves the same response to every request.
+func (r *VoiceResponse) ServeHTTP(w http.ResponseWriter, _ *http.Request) { r.Write(w) }
+
+// GatherVerb is a verb that may be nested in <Gather>: *Say, *Play or
+// *Pause.
+type GatherVerb interface{ gatherVerb() }
+
+// Say reads text aloud.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/say
+type Say struct {
+	XMLName  xml.Name `xml:"Say"`
+	Text     string   `xml:",chardata"`
+	Voice    string   `xml:"voice,attr,omitempty"`
+	Language string   `xml:"language,attr,omitempty"`
+	Loop     int      `xml:"loop,attr,omitempty"`
+}
+
+func (*Say) voiceVerb()  {}
+func (*Say) gatherVerb() {}
+
+// Play plays an audio file, or DTMF tones when Digits is set.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/play
+type Play struct {
+	XMLName xml.Name `xml:"Play"`
+	URL     string   `xml:",chardata"`
+	Loop    int      `xml:"loop,attr,omitempty"`
+	Digits  string   `xml:"digits,attr,omitempty"`
+}
+
+func (*Play) voiceVerb()  {}
+func (*Play) gatherVerb() {}
+
+// Pause waits silently for Length seconds.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/pause
+type Pause struct {
+	XMLName xml.Name `xml:"Pause"`
+	Length  int      `xml:"length,attr,omitempty"`
+}
+
+func (*Pause) voiceVerb()  {}
+func (*Pause) gatherVerb() {}
+
+// Gather collects digits or speech from the caller while its nested verbs
+// play, then requests Action with the result.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/gather
+type Gather struct {
+	XMLName               xml.Name `xml:"Gather"`
+	Action                string   `xml:"action,attr,omitempty"`
+	Method                string   `xml:"method,attr,omitempty"`
+	Input                 string   `xml:"input,attr,omitempty"`
+	Timeout               int      `xml:"timeout,attr,omitempty"`
+	FinishOnKey           string   `xml:"finishOnKey,attr,omitempty"`
+	NumDigits             int      `xml:"numDigits,attr,omitempty"`
+	SpeechTimeout         string   `xml:"speechTimeout,attr,omitempty"`
+	SpeechModel           string   `xml:"speechModel,attr,omitempty"`
+	Hints                 string   `xml:"hints,attr,omitempty"`
+	Language              string   `xml:"language,attr,omitempty"`
+	ProfanityFilter       *bool    `xml:"profanityFilter,attr,omitempty"`
+	ActionOnEmptyResult   bool     `xml:"actionOnEmptyResult,attr,omitempty"`
+	PartialResultCallback string   `xml:"partialResultCallback,attr,omitempty"`
+	Verbs                 []GatherVerb
+}
+
+func (*Gather) voiceVerb() {}
+
+// DialNoun is what <Dial> connects to: *Number, *Client, *Sip, *Conference
+// or *Queue.
+type DialNoun interface{ dialNoun() }
+
+// Dial connects the call to another party, given either as a plain phone
+// number in To or as one or more Nouns.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/dial
+type Dial struct {
+	XMLName                       xml.Name `xml:"Dial"`
+	To                            string   `xml:",chardata"`
+	Action                        string   `xml:"action,attr,omitempty"`
+	Method                        string   `xml:"method,attr,omitempty"`
+	Timeout                       int      `xml:"timeout,attr,omitempty"`
+	HangupOnStar                  bool     `xml:"hangupOnStar,attr,omitempty"`
+	TimeLimit                     int      `xml:"timeLimit,attr,omitempty"`
+	CallerID                      string   `xml:"callerId,attr,omitempty"`
+	AnswerOnBridge                bool     `xml:"answerOnBridge,attr,omitempty"`
+	RingTone                      string   `xml:"ringTone,attr,omitempty"`
+	Record                        string   `xml:"record,attr,omitempty"`
+	Trim                          string   `xml:"trim,attr,omitempty"`
+	RecordingStatusCallback       string   `xml:"recordingStatusCallback,attr,omitempty"`
+	RecordingStatusCallbackMethod string   `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	RecordingStatusCallbackEvent  string   `xml:"recordingStatusCallbackEvent,attr,omitempty"`
+	Nouns                         []DialNoun
+}
+
+func (*Dial) voiceVerb() {}
+
+// Number is a phone number to <Dial>.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/number
+type Number struct {
+	XMLName              xml.Name `xml:"Number"`
+	PhoneNumber          string   `xml:",chardata"`
+	SendDigits           string   `xml:"sendDigits,attr,omitempty"`
+	URL                  string   `xml:"url,attr,omitempty"`
+	Method               string   `xml:"method,attr,omitempty"`
+	StatusCallback       string   `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackEvent  string   `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallbackMethod string   `xml:"statusCallbackMethod,attr,omitempty"`
+}
+
+func (*Number) dialNoun() {}
+
+// Client is a Twilio Client identity to <Dial>.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/client
+type Client struct {
+	XMLName              xml.Name `xml:"Client"`
+	Identity             string   `xml:",chardata"`
+	URL                  string   `xml:"url,attr,omitempty"`
+	Method               string   `xml:"method,attr,omitempty"`
+	StatusCallback       string   `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackEvent  string   `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallbackMethod string   `xml:"statusCallbackMethod,attr,omitempty"`
+}
+
+func (*Client) dialNoun() {}
+
+// Sip is a SIP URI to <Dial>.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/sip
+type Sip struct {
+	XMLName              xml.Name `xml:"Sip"`
+	URI                  string   `xml:",chardata"`
+	Username             string   `xml:"username,attr,omitempty"`
+	Password             string   `xml:"password,attr,omitempty"`
+	URL                  string   `xml:"url,attr,omitempty"`
+	Method               string   `xml:"method,attr,omitempty"`
+	StatusCallback       string   `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackEvent  string   `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallbackMethod string   `xml:"statusCallbackMethod,attr,omitempty"`
+}
+
+func (*Sip) dialNoun() {}
+
+// Conference is a named conference room to <Dial> into.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/conference
+type Conference struct {
+	XMLName                       xml.Name `xml:"Conference"`
+	Name                          string   `xml:",chardata"`
+	Muted                         bool     `xml:"muted,attr,omitempty"`
+	Beep                          string   `xml:"beep,attr,omitempty"`
+	StartConferenceOnEnter        *bool    `xml:"startConferenceOnEnter,attr,omitempty"`
+	EndConferenceOnExit           bool     `xml:"endConferenceOnExit,attr,omitempty"`
+	WaitURL                       string   `xml:"waitUrl,attr,omitempty"`
+	WaitMethod                    string   `xml:"waitMethod,attr,omitempty"`
+	MaxParticipants               int      `xml:"maxParticipants,attr,omitempty"`
+	Record                        string   `xml:"record,attr,omitempty"`
+	Region                        string   `xml:"region,attr,omitempty"`
+	Trim                          string   `xml:"trim,attr,omitempty"`
+	Coach                         string   `xml:"coach,attr,omitempty"`
+	ParticipantLabel              string   `xml:"participantLabel,attr,omitempty"`
+	StatusCallback                string   `xml:"statusCallback,attr,omitempty"`
+	StatusCallbackEvent           string   `xml:"statusCallbackEvent,attr,omitempty"`
+	StatusCallbackMethod          string   `xml:"statusCallbackMethod,attr,omitempty"`
+	RecordingStatusCallback       string   `xml:"recordingStatusCallback,attr,omitempty"`
+	RecordingStatusCallbackMethod string   `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	RecordingStatusCallbackEvent  string   `xml:"recordingStatusCallbackEvent,attr,omitempty"`
+}
+
+func (*Conference) dialNoun() {}
+
+// Queue is a call queue to <Dial>, connecting to the call at its front.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/queue
+type Queue struct {
+	XMLName             xml.Name `xml:"Queue"`
+	Name                string   `xml:",chardata"`
+	URL                 string   `xml:"url,attr,omitempty"`
+	Method              string   `xml:"method,attr,omitempty"`
+	ReservationSID      string   `xml:"reservationSid,attr,omitempty"`
+	PostWorkActivitySID string   `xml:"postWorkActivitySid,attr,omitempty"`
+}
+
+func (*Queue) dialNoun() {}
+
+// Record records the caller and requests Action with the recording's URL.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/record
+type Record struct {
+	XMLName                       xml.Name `xml:"Record"`
+	Action                        string   `xml:"action,attr,omitempty"`
+	Method                        string   `xml:"method,attr,omitempty"`
+	Timeout                       int      `xml:"timeout,attr,omitempty"`
+	FinishOnKey                   string   `xml:"finishOnKey,attr,omitempty"`
+	MaxLength                     int      `xml:"maxLength,attr,omitempty"`
+	PlayBeep                      *bool    `xml:"playBeep,attr,omitempty"`
+	Trim                          string   `xml:"trim,attr,omitempty"`
+	RecordingStatusCallback       string   `xml:"recordingStatusCallback,attr,omitempty"`
+	RecordingStatusCallbackMethod string   `xml:"recordingStatusCallbackMethod,attr,omitempty"`
+	RecordingStatusCallbackEvent  string   `xml:"recordingStatusCallbackEvent,attr,omitempty"`
+	Transcribe                    bool     `xml:"transcribe,attr,omitempty"`
+	TranscribeCallback            string   `xml:"transcribeCallback,attr,omitempty"`
+}
+
+func (*Record) voiceVerb() {}
+
+// Hangup ends the call.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/hangup
+type Hangup struct {
+	XMLName xml.Name `xml:"Hangup"`
+}
+
+func (*Hangup) voiceVerb() {}
+
+// Reject declines an incoming call without answering it, so it is not
+// billed. Reason is "rejected" (the default) or "busy".
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/reject
+type Reject struct {
+	XMLName xml.Name `xml:"Reject"`
+	Reason  string   `xml:"reason,attr,omitempty"`
+}
+
+func (*Reject) voiceVerb() {}
+
+// Enqueue places the call in the named queue, playing WaitURL meanwhile.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml/enqueue
+type Enqueue struct {
+	XMLName       xml.Name `xml:"Enqueue"`
+	Name          string   `xml:",chardata"`
+	Action        string   `xml:"action,attr,omitempty"`
+	Method        string   `xml:"method,attr,omitempty"`
+	WaitURL       string   `xml:"waitUrl,attr,omitempty"`
+	WaitURLMethod string   `xml:"waitUrlMethod,attr,omitempty"`
+	WorkflowSID   string   `xml:"workflowSid,attr,omitempty"`
+}
+
+func (*Enqueue) voiceVerb() {}
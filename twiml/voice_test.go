@@ -0,0 +1,62 @@
+package twiml
+
+import (
+	"encoding/xml"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVoiceResponse(t *testing.T) {
+	resp := NewVoiceResponse(
+		&Say{Text: "Hello & welcome", Voice: "alice", Loop: 2},
+		&Gather{
+			Action:    "/menu",
+			NumDigits: 1,
+			Verbs:     []GatherVerb{&Play{URL: "https://example.com/menu.mp3"}, &Pause{Length: 2}},
+		},
+	).Add(
+		&Dial{
+			CallerID: "+15551231234",
+			Nouns: []DialNoun{
+				&Number{PhoneNumber: "+15559871234", SendDigits: "wwww1928"},
+				&Client{Identity: "jenny"},
+				&Sip{URI: "sip:jack@example.com", Username: "admin"},
+			},
+		},
+		&Dial{Nouns: []DialNoun{&Conference{Name: "Room 1234", StartConferenceOnEnter: Bool(false)}}},
+		&Dial{Nouns: []DialNoun{&Queue{Name: "support", URL: "/about-to-connect"}}},
+		&Dial{To: "+15553214321", Timeout: 10},
+		&Record{MaxLength: 20, PlayBeep: Bool(true), Transcribe: true},
+		&Enqueue{Name: "support", WaitURL: "/wait-music"},
+		&Redirect{URL: "/next", Method: "POST"},
+		&Reject{Reason: "busy"},
+		&Hangup{},
+	)
+
+	expected := xml.Header + `<Response>` +
+		`<Say voice="alice" loop="2">Hello &amp; welcome</Say>` +
+		`<Gather action="/menu" numDigits="1"><Play>https://example.com/menu.mp3</Play><Pause length="2"></Pause></Gather>` +
+		`<Dial callerId="+15551231234"><Number sendDigits="wwww1928">+15559871234</Number><Client>jenny</Client><Sip username="admin">sip:jack@example.com</Sip></Dial>` +
+		`<Dial><Conference startConferenceOnEnter="false">Room 1234</Conference></Dial>` +
+		`<Dial><Queue url="/about-to-connect">support</Queue></Dial>` +
+		`<Dial timeout="10">+15553214321</Dial>` +
+		`<Record maxLength="20" playBeep="true" transcribe="true"></Record>` +
+		`<Enqueue waitUrl="/wait-music">support</Enqueue>` +
+		`<Redirect method="POST">/next</Redirect>` +
+		`<Reject reason="busy"></Reject>` +
+		`<Hangup></Hangup>` +
+		`</Response>`
+	if resp.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, resp.String())
+	}
+
+	w := httptest.NewRecorder()
+	resp.ServeHTTP(w, httptest.NewRequest("POST", "/voice", nil))
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/xml") {
+		t.Fatalf("unexpected Content-Type: %s", ct)
+	}
+	if w.Body.String() != expected {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
@@ -31,3 +31,11 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 	t.Time = ot
 	return nil
 }
+
+// Bool returns a pointer to b, for the optional flags of ParticipantReq,
+// ParticipantUpdate and PhoneNumberConfig that are only sent when set.
+func Bool(b bool) *bool { return &b }
+
+// String returns a pointer to s, for the settings of PhoneNumberConfig that
+// are only sent when set.
+func String(s string) *string { return &s }
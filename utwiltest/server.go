@@ -0,0 +1,364 @@
+// Package utwiltest provides an in-process fake of the Twilio REST and
+// Lookups APIs, so code using utwil can be tested without network access or
+// charges to a Twilio account.
+//
+// Start with:
+//
+//	srv := utwiltest.NewServer()
+//	defer srv.Close()
+//	client := utwil.NewClient(srv.AccountSID, srv.AuthToken)
+//	client.BaseURL = srv.URL
+//	client.LookupURL = srv.LookupURL()
+//
+// Resources created through the API (or seeded with Server.Add) are kept in
+// memory and served back with Twilio's paging and the list filters Twilio
+// supports (others are ignored), and every request is recorded for
+// assertions:
+//
+//	msg, err := client.SendSMS("+15551231234", "+15553214321", "Hello, world!")
+//	req := srv.AssertRequested(t, "POST", "/Messages.json")
+//	fmt.Println(req.Form.Get("Body")) // "Hello, world!"
+package utwiltest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIVersion is the REST API version the fake serves
+const APIVersion = "2010-04-01"
+
+// Resource is the JSON representation of a fake Twilio resource, keyed by
+// Twilio's snake_case field names.
+type Resource map[string]interface{}
+
+// Request is a request received by the fake, recorded for assertions.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Form   url.Values
+	Header http.Header
+}
+
+// Server is an httptest.Server faking the Twilio API. Its exported fields
+// may be changed before the first request is made.
+type Server struct {
+	*httptest.Server
+	AccountSID string
+	AuthToken  string
+	// PageSize is used for list requests that do not specify PageSize
+	PageSize int
+
+	m         sync.Mutex
+	resources map[string][]Resource
+	lookups   map[string]Resource
+	contents  map[string]content
+	requests  []Request
+	failures  []restError
+}
+
+// NewServer starts a fake Twilio API with random credentials. Close it when
+// done.
+func NewServer() *Server {
+	s := &Server{
+		AccountSID: NewSID("AC"),
+		AuthToken:  randomHex(16),
+		PageSize:   50,
+		resources:  make(map[string][]Resource),
+		lookups:    make(map[string]Resource),
+		contents:   make(map[string]content),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// LookupURL returns the URL to use as the Lookups API host
+func (s *Server) LookupURL() string {
+	return s.URL + "/v1"
+}
+
+// NewSID returns a random SID with the given two letter prefix, e.g. "CA"
+func NewSID(prefix string) string {
+	return prefix + randomHex(16)
+}
+
+func randomDigits(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	for i := range buf {
+		buf[i] = '0' + buf[i]%10
+	}
+	return string(buf)
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// restError is the JSON body of a Twilio error response, mirroring
+// utwil.RESTException.
+type restError struct {
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	MoreInfo string `json:"more_info"`
+	Status   int    `json:"status"`
+}
+
+func (e *restError) Error() string { return e.Message }
+
+func newError(status, code int, format string, args ...interface{}) *restError {
+	return &restError{
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		MoreInfo: fmt.Sprintf("https://www.twilio.com/docs/errors/%d", code),
+		Status:   status,
+	}
+}
+
+func notFound(path string) *restError {
+	return newError(404, 20404, "The requested resource %s was not found", path)
+}
+
+// content is a binary file served by the fake, such as media or recordings
+type content struct {
+	contentType string
+	data        []byte
+}
+
+// SetContent serves data as the binary content at path, relative to the
+// account, e.g. "Messages/MM.../Media/ME..." for the content of a media file.
+func (s *Server) SetContent(path, contentType string, data []byte) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.contents[s.accountPath(path)] = content{contentType, data}
+}
+
+// FailNext makes the next request fail with the given HTTP status and Twilio
+// error code, e.g. FailNext(429, 20429, "Too Many Requests"). Failures queue
+// up, one per request.
+func (s *Server) FailNext(status, code int, message string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.failures = append(s.failures, *newError(status, code, "%s", message))
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Form:   r.PostForm,
+		Header: r.Header,
+	})
+
+	if len(s.failures) > 0 {
+		failure := s.failures[0]
+		s.failures = s.failures[1:]
+		writeJSON(w, failure.Status, failure)
+		return
+	}
+	user, pass, ok := r.BasicAuth()
+	if !ok || user != s.AccountSID || pass != s.AuthToken {
+		writeJSON(w, 401, newError(401, 20003, "Authenticate"))
+		return
+	}
+
+	var result interface{}
+	var err *restError
+	status := 200
+	switch path := r.URL.Path; {
+	case strings.HasPrefix(path, "/v1/PhoneNumbers/") && r.Method == "GET":
+		result, err = s.lookup(r)
+	case strings.HasPrefix(path, "/"+APIVersion+"/Accounts") && strings.HasSuffix(path, ".json"):
+		status, result, err = s.serveREST(r)
+	case strings.HasPrefix(path, "/"+APIVersion+"/Accounts/") && r.Method == "GET":
+		c, ok := s.contents[strings.TrimPrefix(path, "/"+APIVersion+"/")]
+		if !ok {
+			err = notFound(path)
+			break
+		}
+		w.Header().Set("Content-Type", c.contentType)
+		w.Write(c.data)
+		return
+	default:
+		err = notFound(path)
+	}
+
+	if err != nil {
+		writeJSON(w, err.Status, err)
+	} else if status == 204 {
+		w.WriteHeader(204)
+	} else {
+		writeJSON(w, status, result)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// serveREST dispatches a REST API request on its path, e.g.
+// "Accounts/AC.../Calls" is a collection and "Accounts/AC.../Calls/CA..." is
+// an item of it.
+func (s *Server) serveREST(r *http.Request) (int, interface{}, *restError) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.EscapedPath(), "/"+APIVersion+"/"), ".json")
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		if seg, err := url.PathUnescape(seg); err == nil {
+			segs[i] = seg
+		}
+	}
+	s.addMainAccount()
+	if len(segs) > 1 {
+		account, ok := s.find("Accounts", segs[1])
+		if !ok {
+			return 0, nil, notFound(r.URL.Path)
+		}
+		if len(segs) > 2 && account["status"] != "active" {
+			return 0, nil, newError(401, 20005, "Account not active")
+		}
+	}
+
+	// conferences may be referred to by friendly name
+	if len(segs) > 3 && segs[2] == "Conferences" {
+		addParticipant := len(segs) == 5 && segs[4] == "Participants" && r.Method == "POST"
+		segs[3] = s.conferenceSID(strings.Join(segs[:3], "/"), segs[3], addParticipant)
+	}
+	path = strings.Join(segs, "/")
+
+	// numbers of a type are listed at e.g. "Accounts/AC.../IncomingPhoneNumbers/Local"
+	if len(segs) == 4 && segs[2] == "IncomingPhoneNumbers" && numberTypes[segs[3]] && r.Method == "GET" {
+		result, err := s.list(r, strings.Join(segs[:3], "/"), url.Values{"NumberType": {segs[3]}})
+		return 200, result, err
+	}
+
+	if len(segs)%2 == 1 {
+		switch r.Method {
+		case "GET":
+			result, err := s.list(r, path, nil)
+			return 200, result, err
+		case "POST":
+			res, err := s.create(path, r.PostForm)
+			return 201, res, err
+		}
+	} else {
+		collection, sid := strings.Join(segs[:len(segs)-1], "/"), segs[len(segs)-1]
+		switch r.Method {
+		case "GET":
+			res, err := s.fetch(collection, sid)
+			return 200, res, err
+		case "POST":
+			res, err := s.update(collection, sid, r.PostForm)
+			return 200, res, err
+		case "DELETE":
+			return 204, nil, s.remove(collection, sid)
+		}
+	}
+	return 0, nil, newError(405, 20004, "Method not allowed")
+}
+
+// addMainAccount adds the account of the server's credentials to the
+// "Accounts" collection, as it may have changed since NewServer
+func (s *Server) addMainAccount() {
+	if _, ok := s.find("Accounts", s.AccountSID); ok {
+		return
+	}
+	account := s.fill("Accounts", Resource{
+		"sid":           s.AccountSID,
+		"friendly_name": "utwiltest",
+		"type":          "Full",
+	})
+	createAccount(s, account, nil)
+	s.resources["Accounts"] = append([]Resource{account}, s.resources["Accounts"]...)
+}
+
+// Requests returns every request received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// LastRequest returns the most recent request, if any.
+func (s *Server) LastRequest() (Request, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if len(s.requests) == 0 {
+		return Request{}, false
+	}
+	return s.requests[len(s.requests)-1], true
+}
+
+// ResetRequests forgets the recorded requests.
+func (s *Server) ResetRequests() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.requests = nil
+}
+
+// TB is the subset of testing.TB used by the assertion helpers.
+type TB interface {
+	Helper()
+	Fatalf(format string, args ...interface{})
+}
+
+// FindRequests returns the recorded requests with the given method whose path
+// ends in pathSuffix, e.g. ("POST", "/Messages.json").
+func (s *Server) FindRequests(method, pathSuffix string) []Request {
+	var found []Request
+	for _, req := range s.Requests() {
+		if req.Method == method && strings.HasSuffix(req.Path, pathSuffix) {
+			found = append(found, req)
+		}
+	}
+	return found
+}
+
+// AssertRequested fails the test unless a request matching method and
+// pathSuffix was received, and returns the latest such request.
+func (s *Server) AssertRequested(t TB, method, pathSuffix string) Request {
+	t.Helper()
+	found := s.FindRequests(method, pathSuffix)
+	if len(found) == 0 {
+		t.Fatalf("utwiltest: no %s request to *%s among %d requests",
+			method, pathSuffix, len(s.Requests()))
+		return Request{}
+	}
+	return found[len(found)-1]
+}
+
+// AssertRequestCount fails the test unless exactly n requests matching method
+// and pathSuffix were received.
+func (s *Server) AssertRequestCount(t TB, method, pathSuffix string, n int) {
+	t.Helper()
+	if found := len(s.FindRequests(method, pathSuffix)); found != n {
+		t.Fatalf("utwiltest: expected %d %s requests to *%s, got %d",
+			n, method, pathSuffix, found)
+	}
+}
+
+// formatTime formats t as Twilio does in JSON responses
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC1123Z)
+}
@@ -0,0 +1,135 @@
+package utwiltest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wyc/utwil"
+	"github.com/wyc/utwil/utwiltest"
+)
+
+func newClient(srv *utwiltest.Server) utwil.Client {
+	client := utwil.NewClient(srv.AccountSID, srv.AuthToken)
+	client.BaseURL = srv.URL
+	client.LookupURL = srv.LookupURL()
+	client.RetryPolicy = nil
+	return client
+}
+
+func TestServerAuth(t *testing.T) {
+	srv := utwiltest.NewServer()
+	defer srv.Close()
+	client := newClient(srv)
+	client.AuthToken = "wrong"
+
+	_, err := client.Lookup("+15551231234")
+	re, ok := err.(utwil.RESTException)
+	if !ok || re.Code == nil || *re.Code != 20003 {
+		t.Fatalf("expected error 20003, got: %v", err)
+	}
+}
+
+func TestServerFailNext(t *testing.T) {
+	srv := utwiltest.NewServer()
+	defer srv.Close()
+	client := newClient(srv)
+
+	srv.FailNext(503, 20503, "Service Unavailable")
+	if _, err := client.Lookup("+15551231234"); err == nil {
+		t.Fatalf("expected the queued failure")
+	}
+	lookup, err := client.Lookup("+15551231234")
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if lookup.Carrier == nil || lookup.Carrier.Type != "mobile" {
+		t.Fatalf("unexpected lookup: %+v", lookup)
+	}
+	srv.AssertRequestCount(t, "GET", "/PhoneNumbers/+15551231234", 2)
+}
+
+func TestServerCalls(t *testing.T) {
+	srv := utwiltest.NewServer()
+	defer srv.Close()
+	client := newClient(srv)
+
+	old := time.Date(2015, 3, 1, 12, 0, 0, 0, time.UTC).Format(time.RFC1123Z)
+	srv.Add("Calls", utwiltest.Resource{"from": "+15551231234", "start_time": old})
+	if _, err := client.Call("+15551231234", "+15553214321", "http://example.com/twiml"); err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	req := srv.AssertRequested(t, "POST", "/Calls.json")
+	if req.Form.Get("Url") != "http://example.com/twiml" {
+		t.Fatalf("unexpected form: %v", req.Form)
+	}
+	calls := srv.List("Calls")
+	if len(calls) != 2 || calls[1]["status"] != "queued" {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	srv.Add("Calls", utwiltest.Resource{
+		"from":       "+15551231234",
+		"start_time": time.Now().Format(time.RFC1123Z),
+	})
+
+	iter := client.Calls(
+		utwil.From("+15551231234"),
+		utwil.StartedAfter("2015-04-01")).Iter()
+	var call utwil.Call
+	count := 0
+	for iter.Next(&call) {
+		count++
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 call started after 2015-04-01, got %d", count)
+	}
+}
+
+// Resources lacking the field of a filter never match it, e.g. a message
+// added without a sender is not listed as sent from any number
+func TestServerFilterMissingField(t *testing.T) {
+	srv := utwiltest.NewServer()
+	defer srv.Close()
+	client := newClient(srv)
+
+	srv.Add("Messages", utwiltest.Resource{"from": "+15551231234", "body": "Hello, world!"})
+	srv.Add("Messages", utwiltest.Resource{"body": "Hello, world!"})
+
+	iter := client.Messages(utwil.From("+15551231234")).Iter()
+	var msg utwil.Message
+	count := 0
+	for iter.Next(&msg) {
+		count++
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 message from +15551231234, got %d", count)
+	}
+}
+
+// Filters Twilio does not support on a list are ignored, as Twilio does
+func TestServerUnsupportedFilter(t *testing.T) {
+	srv := utwiltest.NewServer()
+	defer srv.Close()
+	client := newClient(srv)
+
+	srv.Add("Queues", utwiltest.Resource{"friendly_name": "support"})
+	srv.Add("Queues", utwiltest.Resource{"friendly_name": "sales"})
+
+	iter := client.Queues(utwil.FriendlyName("support")).Iter()
+	var queue utwil.Queue
+	count := 0
+	for iter.Next(&queue) {
+		count++
+	}
+	if iter.Err() != nil {
+		t.Fatalf("error: %s", iter.Err().Error())
+	}
+	if count != 2 {
+		t.Fatalf("expected the FriendlyName filter to be ignored, got %d queues", count)
+	}
+}
@@ -0,0 +1,854 @@
+package utwiltest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// kind describes how the fake treats a collection, keyed by the collection's
+// last path segment, e.g. "Calls".
+type kind struct {
+	prefix  string // SID prefix of created resources
+	idField string // field matched against the SID in item paths
+	listKey string // JSON key of the items in list responses
+	fifo    bool   // list oldest first, like the members of a queue
+
+	// filters are the list filters Twilio supports, e.g. "StartTime" for
+	// "StartTime>"; others are ignored like Twilio does
+	filters []string
+
+	// match replaces matchesFilters for kinds with their own search params
+	match func(res Resource, query url.Values) bool
+
+	// create validates and fills in a resource created from form values
+	create func(s *Server, res Resource, form url.Values) *restError
+	// update applies side effects of updating res with form values
+	update func(s *Server, res Resource, form url.Values) *restError
+}
+
+// kinds is set up by init, as the hooks of some kinds look up others
+var kinds map[string]*kind
+
+func init() {
+	available := &kind{idField: "phone_number", listKey: "available_phone_numbers", match: matchesAvailable}
+	kinds = map[string]*kind{
+		"Calls": {prefix: "CA", create: createCall,
+			filters: []string{"To", "From", "ParentCallSid", "Status", "StartTime", "EndTime"}},
+		"Messages": {prefix: "SM", create: createMessage,
+			filters: []string{"To", "From", "DateSent"}},
+		"Media": {prefix: "ME", listKey: "media_list",
+			filters: []string{"DateCreated"}},
+		"Recordings": {prefix: "RE", create: createRecording,
+			filters: []string{"DateCreated", "CallSid", "ConferenceSid"}},
+		"Notifications": {prefix: "NO",
+			filters: []string{"Log", "MessageDate"}},
+		"Conferences": {prefix: "CF",
+			filters: []string{"DateCreated", "DateUpdated", "FriendlyName", "Status"}},
+		"Participants": {idField: "call_sid", create: createParticipant,
+			filters: []string{"Muted", "Hold", "Coaching"}},
+		"Transcriptions": {prefix: "TR"},
+		"Queues":         {prefix: "QU", create: createQueue, update: updateQueue},
+		"IncomingPhoneNumbers": {prefix: "PN", create: createIncomingPhoneNumber,
+			filters: []string{"Beta", "FriendlyName", "PhoneNumber", "Origin"}},
+		"Local":    available,
+		"Mobile":   available,
+		"TollFree": available,
+		"Accounts": {prefix: "AC", create: createAccount, update: updateAccount,
+			filters: []string{"FriendlyName", "Status"}},
+		"Members": {idField: "call_sid", listKey: "queue_members", fifo: true, update: dequeueMember},
+	}
+	for name, k := range kinds {
+		if k.idField == "" {
+			k.idField = "sid"
+		}
+		if k.listKey == "" {
+			k.listKey = snakeCase(name)
+		}
+	}
+}
+
+// Aliases Twilio accepts in item paths in place of a SID
+const (
+	currentRecording = "Twilio.CURRENT" // the recording active on a call
+	frontMember      = "Front"          // the member at the front of a queue
+)
+
+// kindOf returns the kind of a collection. It must not be modified, as kinds
+// are shared by every Server.
+func kindOf(collection string) *kind {
+	segs := strings.Split(collection, "/")
+	name := segs[len(segs)-1]
+	if k, ok := kinds[name]; ok {
+		return k
+	}
+	return &kind{prefix: "ZZ", idField: "sid", listKey: snakeCase(name)}
+}
+
+func createCall(s *Server, res Resource, form url.Values) *restError {
+	switch {
+	case form.Get("To") == "":
+		return newError(400, 21201, "No 'To' number is specified")
+	case form.Get("From") == "":
+		return newError(400, 21213, "No 'From' number is specified")
+	case form.Get("Url") == "" && form.Get("Twiml") == "" && form.Get("ApplicationSid") == "":
+		return newError(400, 21205, "Url parameter is required")
+	}
+	setDefaults(res, Resource{
+		"status":      "queued",
+		"direction":   "outbound-api",
+		"api_version": APIVersion,
+		"subresource_uris": map[string]string{
+			"notifications": subresourceURI(res, "Notifications"),
+			"recordings":    subresourceURI(res, "Recordings"),
+		},
+	})
+	return nil
+}
+
+func createMessage(s *Server, res Resource, form url.Values) *restError {
+	switch {
+	case form.Get("To") == "":
+		return newError(400, 21604, "A 'To' phone number is required.")
+	case form.Get("From") == "" && form.Get("MessagingServiceSid") == "":
+		return newError(400, 21603, "A 'From' phone number is required.")
+	case form.Get("Body") == "" && len(form["MediaUrl"]) == 0 && form.Get("ContentSid") == "":
+		return newError(400, 21602, "Message body is required.")
+	}
+	delete(res, "media_url")
+	if form.Get("ScheduleType") != "" {
+		res["status"] = "scheduled"
+	} else {
+		// The fake sends messages right away
+		res["date_sent"] = res["date_created"]
+	}
+	setDefaults(res, Resource{
+		"status":       "queued",
+		"direction":    "outbound-api",
+		"api_version":  APIVersion,
+		"num_media":    strconv.Itoa(len(form["MediaUrl"])),
+		"num_segments": "1",
+		"subresource_uris": map[string]string{
+			"media": subresourceURI(res, "Media"),
+		},
+	})
+	return nil
+}
+
+// createRecording starts recording a call. The recording is listed both
+// under the call and under the account, like Twilio does.
+func createRecording(s *Server, res Resource, form url.Values) *restError {
+	segs := strings.Split(res["uri"].(string), "/")
+	// /2010-04-01/Accounts/AC.../Calls/CA.../Recordings/RE....json
+	if len(segs) != 8 || segs[4] != "Calls" {
+		return newError(405, 20004, "Method not allowed")
+	}
+	callSID := segs[5]
+	if _, ok := s.find(strings.Join(segs[2:5], "/"), callSID); !ok {
+		return notFound(strings.Join(segs[:6], "/") + ".json")
+	}
+	for _, field := range []string{"recording_status_callback", "recording_status_callback_method",
+		"recording_status_callback_event", "recording_channels", "recording_track", "trim"} {
+		delete(res, field)
+	}
+	channels := 1
+	if form.Get("RecordingChannels") == "dual" {
+		channels = 2
+	}
+	setDefaults(res, Resource{
+		"call_sid":    callSID,
+		"status":      "in-progress",
+		"source":      "StartCallRecordingAPI",
+		"channels":    channels,
+		"duration":    "-1",
+		"start_time":  res["date_created"],
+		"api_version": APIVersion,
+		"track":       "both",
+		"subresource_uris": map[string]string{
+			"transcriptions": fmt.Sprintf("/%s/%s/Recordings/%s/Transcriptions.json",
+				APIVersion, strings.Join(segs[2:4], "/"), res["sid"]),
+		},
+	})
+	account := strings.Join(segs[2:4], "/") + "/Recordings"
+	s.resources[account] = append(s.resources[account], res)
+	return nil
+}
+
+// participantFields are the fields of a participant kept from the form
+var participantFields = map[string]bool{
+	"account_sid": true, "date_created": true, "date_updated": true,
+	"label": true, "muted": true, "coaching": true, "call_sid_to_coach": true,
+	"start_conference_on_enter": true, "end_conference_on_exit": true,
+}
+
+// createParticipant dials a participant into a conference, creating the
+// participant's call.
+func createParticipant(s *Server, res Resource, form url.Values) *restError {
+	switch {
+	case form.Get("To") == "":
+		return newError(400, 21201, "No 'To' number is specified")
+	case form.Get("From") == "":
+		return newError(400, 21213, "No 'From' number is specified")
+	}
+	segs := strings.Split(res["uri"].(string), "/")
+	// /2010-04-01/Accounts/AC.../Conferences/CF.../Participants/.json
+	confSID, calls := segs[5], strings.Join(segs[2:4], "/")+"/Calls"
+	if _, ok := s.find(strings.Join(segs[2:5], "/"), confSID); !ok {
+		return notFound(strings.Join(segs[:6], "/") + ".json")
+	}
+	call := s.fill(calls, Resource{
+		"to":        form.Get("To"),
+		"from":      form.Get("From"),
+		"status":    "queued",
+		"direction": "outbound-api",
+	})
+	s.resources[calls] = append(s.resources[calls], call)
+
+	callSID := call["sid"].(string)
+	for k := range res {
+		if !participantFields[k] {
+			delete(res, k)
+		}
+	}
+	res["call_sid"] = callSID
+	res["conference_sid"] = confSID
+	res["uri"] = fmt.Sprintf("%s/%s.json", strings.Join(segs[:7], "/"), callSID)
+	setDefaults(res, Resource{
+		"status":                    "queued",
+		"muted":                     false,
+		"hold":                      false,
+		"coaching":                  false,
+		"start_conference_on_enter": true,
+		"end_conference_on_exit":    false,
+	})
+	return nil
+}
+
+// conferenceSID resolves the friendly name of a conference that has not
+// completed to its SID. Adding a participant to a conference that does not
+// exist yet starts it, like Twilio does.
+func (s *Server) conferenceSID(collection, name string, create bool) string {
+	if _, ok := s.find(collection, name); ok {
+		return name
+	}
+	for _, res := range s.resources[collection] {
+		if res["friendly_name"] == name && res["status"] != "completed" {
+			return res["sid"].(string)
+		}
+	}
+	if !create {
+		return name
+	}
+	conf := s.fill(collection, Resource{"friendly_name": name, "status": "init"})
+	s.resources[collection] = append(s.resources[collection], conf)
+	return conf["sid"].(string)
+}
+
+func createQueue(s *Server, res Resource, form url.Values) *restError {
+	if form.Get("FriendlyName") == "" {
+		return newError(400, 20001, "Missing required parameter FriendlyName in the post body")
+	}
+	res["max_size"] = 100
+	setDefaults(res, Resource{"current_size": 0, "average_wait_time": 0})
+	return updateQueue(s, res, form)
+}
+
+// updateQueue keeps max_size a number, as in Twilio's JSON
+func updateQueue(s *Server, res Resource, form url.Values) *restError {
+	if form.Get("MaxSize") == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(form.Get("MaxSize"))
+	if err != nil || n < 1 || n > 5000 {
+		return newError(400, 20001, "MaxSize must be between 1 and 5000")
+	}
+	res["max_size"] = n
+	return nil
+}
+
+// dequeueMember takes a member out of its queue, to be redirected to Url
+func dequeueMember(s *Server, res Resource, form url.Values) *restError {
+	if form.Get("Url") == "" {
+		return newError(400, 21205, "Url parameter is required")
+	}
+	delete(res, "url")
+	delete(res, "method")
+	uri := res["uri"].(string)
+	collection := strings.TrimPrefix(uri[:strings.LastIndex(uri, "/")], "/"+APIVersion+"/")
+	return s.remove(collection, res["call_sid"].(string))
+}
+
+// numberTypes are the types of phone numbers, which the fake keeps in the
+// number_type field of incoming phone numbers
+var numberTypes = map[string]bool{"Local": true, "Mobile": true, "TollFree": true}
+
+var tollFree = regexp.MustCompile(`^\+18(00|33|44|55|66|77|88)`)
+
+// createIncomingPhoneNumber purchases a phone number. Any number is
+// available; with AreaCode a random one in it is picked.
+func createIncomingPhoneNumber(s *Server, res Resource, form url.Values) *restError {
+	number := form.Get("PhoneNumber")
+	switch {
+	case number == "" && form.Get("AreaCode") == "":
+		return newError(400, 21451, "Either PhoneNumber or AreaCode is required")
+	case number == "":
+		number = "+1" + form.Get("AreaCode") + randomDigits(7)
+	case !phoneNumber.MatchString(number):
+		return newError(400, 21421, "PhoneNumber is invalid")
+	}
+	delete(res, "area_code")
+	res["phone_number"] = number
+	numberType := "Local"
+	if tollFree.MatchString(number) {
+		numberType = "TollFree"
+	}
+	friendlyName := number
+	if len(number) == 12 && strings.HasPrefix(number, "+1") {
+		friendlyName = fmt.Sprintf("(%s) %s-%s", number[2:5], number[5:8], number[8:])
+	}
+	setDefaults(res, Resource{
+		"friendly_name": friendlyName,
+		"number_type":   numberType,
+		"status":        "in-use",
+		"origin":        "twilio",
+		"beta":          false,
+		"api_version":   APIVersion,
+		"capabilities":  Resource{"voice": true, "sms": true, "mms": true, "fax": false},
+	})
+	return nil
+}
+
+// createAccount creates a subaccount of the server's account
+func createAccount(s *Server, res Resource, form url.Values) *restError {
+	delete(res, "account_sid")
+	uris := make(map[string]string)
+	for _, name := range []string{"Calls", "Conferences", "IncomingPhoneNumbers", "Messages",
+		"Notifications", "Queues", "Recordings", "Transcriptions"} {
+		uris[snakeCase(name)] = subresourceURI(res, name)
+	}
+	setDefaults(res, Resource{
+		"friendly_name":     "SubAccount Created at " + formatTime(time.Now()),
+		"owner_account_sid": s.AccountSID,
+		"auth_token":        randomHex(16),
+		"status":            "active",
+		"type":              "Full",
+		"subresource_uris":  uris,
+	})
+	if res["sid"] == s.AccountSID {
+		res["auth_token"] = s.AuthToken
+	}
+	return nil
+}
+
+// updateAccount only allows the statuses an account can be set to. The
+// server's own account cannot be suspended or closed.
+func updateAccount(s *Server, res Resource, form url.Values) *restError {
+	switch status := form.Get("Status"); {
+	case status == "":
+	case status != "active" && status != "suspended" && status != "closed":
+		return newError(400, 20001, "Status is invalid")
+	case res["sid"] == s.AccountSID && status != "active":
+		return newError(400, 20001, "The account of the request's credentials cannot be %s", status)
+	}
+	return nil
+}
+
+func setDefaults(res, defaults Resource) {
+	for k, v := range defaults {
+		if _, ok := res[k]; !ok {
+			res[k] = v
+		}
+	}
+}
+
+func subresourceURI(res Resource, name string) string {
+	return fmt.Sprintf("%s/%s.json", strings.TrimSuffix(res["uri"].(string), ".json"), name)
+}
+
+// Add seeds the collection at path with res and returns the stored resource,
+// with sid, account_sid, uri and dates filled in unless already set. Paths
+// are relative to the account, e.g. "Calls" or "Calls/CA.../Recordings".
+func (s *Server) Add(path string, res Resource) Resource {
+	s.m.Lock()
+	defer s.m.Unlock()
+	collection := s.accountPath(path)
+	res = s.fill(collection, copyResource(res))
+	s.resources[collection] = append(s.resources[collection], res)
+	return copyResource(res)
+}
+
+// Get returns the resource with the given SID from the collection at path,
+// relative to the account.
+func (s *Server) Get(path, sid string) (Resource, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	res, ok := s.find(s.accountPath(path), sid)
+	return copyResource(res), ok
+}
+
+// List returns the resources in the collection at path, relative to the
+// account, oldest first.
+func (s *Server) List(path string) []Resource {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var list []Resource
+	for _, res := range s.resources[s.accountPath(path)] {
+		list = append(list, copyResource(res))
+	}
+	return list
+}
+
+// SetLookup sets the result of looking up phoneNumber. Numbers without one
+// get a generic US mobile result.
+func (s *Server) SetLookup(phoneNumber string, res Resource) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.lookups[phoneNumber] = copyResource(res)
+}
+
+func (s *Server) accountPath(path string) string {
+	return fmt.Sprintf("Accounts/%s/%s", s.AccountSID, strings.Trim(path, "/"))
+}
+
+func copyResource(res Resource) Resource {
+	if res == nil {
+		return nil
+	}
+	cp := make(Resource, len(res))
+	for k, v := range res {
+		cp[k] = v
+	}
+	return cp
+}
+
+// fill sets the fields every resource has
+func (s *Server) fill(collection string, res Resource) Resource {
+	k := kindOf(collection)
+	now := formatTime(time.Now())
+	if _, ok := res["sid"]; !ok && k.idField == "sid" {
+		res["sid"] = NewSID(k.prefix)
+	}
+	id, _ := res[k.idField].(string)
+	setDefaults(res, Resource{
+		"account_sid":  s.accountOf(collection),
+		"date_created": now,
+		"date_updated": now,
+		"uri":          fmt.Sprintf("/%s/%s/%s.json", APIVersion, collection, id),
+	})
+	return res
+}
+
+func (s *Server) accountOf(collection string) string {
+	segs := strings.Split(collection, "/")
+	if len(segs) > 1 {
+		return segs[1]
+	}
+	return s.AccountSID
+}
+
+func (s *Server) find(collection, sid string) (Resource, bool) {
+	idField := kindOf(collection).idField
+	switch {
+	case sid == currentRecording:
+		return s.activeRecording(collection)
+	case sid == frontMember && len(s.resources[collection]) > 0 && kindOf(collection).fifo:
+		return s.resources[collection][0], true
+	}
+	for _, res := range s.resources[collection] {
+		if res[idField] == sid {
+			return res, true
+		}
+	}
+	return nil, false
+}
+
+// activeRecording finds the latest recording of a call that is still going
+func (s *Server) activeRecording(collection string) (Resource, bool) {
+	list := s.resources[collection]
+	for i := len(list) - 1; i >= 0; i-- {
+		if status := list[i]["status"]; status == "in-progress" || status == "paused" {
+			return list[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) create(collection string, form url.Values) (Resource, *restError) {
+	res := formResource(form)
+	res = s.fill(collection, res)
+	if create := kindOf(collection).create; create != nil {
+		if err := create(s, res, form); err != nil {
+			return nil, err
+		}
+	}
+	s.resources[collection] = append(s.resources[collection], res)
+	return res, nil
+}
+
+func (s *Server) fetch(collection, sid string) (Resource, *restError) {
+	res, ok := s.find(collection, sid)
+	if !ok {
+		return nil, notFound(fmt.Sprintf("/%s/%s/%s.json", APIVersion, collection, sid))
+	}
+	return res, nil
+}
+
+func (s *Server) update(collection, sid string, form url.Values) (Resource, *restError) {
+	res, err := s.fetch(collection, sid)
+	if err != nil {
+		return nil, err
+	}
+	// work on a copy so that rejected updates leave res untouched
+	updated := copyResource(res)
+	for k, v := range formResource(form) {
+		updated[k] = v
+	}
+	updated["date_updated"] = formatTime(time.Now())
+	if update := kindOf(collection).update; update != nil {
+		if err := update(s, updated, form); err != nil {
+			return nil, err
+		}
+	}
+	// res may be listed in several collections, so update it in place
+	for k := range res {
+		delete(res, k)
+	}
+	for k, v := range updated {
+		res[k] = v
+	}
+	return res, nil
+}
+
+func (s *Server) remove(collection, sid string) *restError {
+	if _, err := s.fetch(collection, sid); err != nil {
+		return err
+	}
+	idField := kindOf(collection).idField
+	list := s.resources[collection]
+	for i, res := range list {
+		if res[idField] == sid {
+			s.resources[collection] = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// formResource turns form values into resource fields, e.g. StatusCallback
+// becomes status_callback. Flags become booleans as in Twilio's JSON.
+func formResource(form url.Values) Resource {
+	res := make(Resource)
+	for k, vs := range form {
+		if len(vs) == 1 && (vs[0] == "true" || vs[0] == "false") {
+			res[snakeCase(k)] = vs[0] == "true"
+		} else if len(vs) == 1 {
+			res[snakeCase(k)] = vs[0]
+		} else {
+			res[snakeCase(k)] = vs
+		}
+	}
+	return res
+}
+
+// snakeCase converts Twilio parameter names to JSON field names
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// pagingParams are list query parameters that are not filters
+var pagingParams = map[string]bool{"Page": true, "PageSize": true, "PageToken": true}
+
+// list serves a page of the collection, newest first as Twilio does for all
+// but queue members. Only resources matching both the query and where are
+// listed.
+func (s *Server) list(r *http.Request, collection string, where url.Values) (Resource, *restError) {
+	query := r.URL.Query()
+	var matches []Resource
+	all := s.resources[collection]
+	k := kindOf(collection)
+	match, filters := matchesFilters, query
+	if k.match != nil {
+		match = k.match
+	} else {
+		filters = k.supportedFilters(query)
+	}
+	for i := range all {
+		if !k.fifo {
+			i = len(all) - 1 - i
+		}
+		if match(all[i], filters) && matchesFilters(all[i], where) {
+			matches = append(matches, all[i])
+		}
+	}
+
+	pageSize := s.PageSize
+	if n, err := strconv.Atoi(query.Get("PageSize")); err == nil && n > 0 {
+		pageSize = n
+	}
+	page, _ := strconv.Atoi(query.Get("Page"))
+	numPages := int(math.Ceil(float64(len(matches)) / float64(pageSize)))
+	if numPages == 0 {
+		numPages = 1
+	}
+
+	start := page * pageSize
+	end := start + pageSize
+	if start > len(matches) {
+		start = len(matches)
+	}
+	if end > len(matches) {
+		end = len(matches)
+	}
+	items := matches[start:end]
+	if items == nil {
+		items = []Resource{}
+	}
+
+	pageURI := func(p int) string {
+		q := make(url.Values)
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("Page", strconv.Itoa(p))
+		q.Set("PageSize", strconv.Itoa(pageSize))
+		return fmt.Sprintf("%s?%s", r.URL.Path, q.Encode())
+	}
+	var next, previous interface{}
+	if page+1 < numPages {
+		next = pageURI(page + 1)
+	}
+	if page > 0 {
+		previous = pageURI(page - 1)
+	}
+	return Resource{
+		k.listKey:           items,
+		"page":              page,
+		"page_size":         pageSize,
+		"num_pages":         numPages,
+		"start":             start,
+		"end":               start + len(items) - 1,
+		"total":             len(matches),
+		"uri":               r.URL.RequestURI(),
+		"first_page_uri":    pageURI(0),
+		"last_page_uri":     pageURI(numPages - 1),
+		"next_page_uri":     next,
+		"previous_page_uri": previous,
+	}, nil
+}
+
+// filterParam splits filters such as "StartTime>" or "DateSent<=" into the
+// field they apply to and their comparison operator
+var filterParam = regexp.MustCompile(`^(\w+?)([<>]?)=?$`)
+
+// supportedFilters returns the filters of query that the kind supports
+func (k *kind) supportedFilters(query url.Values) url.Values {
+	supported := url.Values{}
+	for param, values := range query {
+		m := filterParam.FindStringSubmatch(param)
+		if m == nil {
+			continue
+		}
+		for _, filter := range k.filters {
+			if m[1] == filter {
+				supported[param] = values
+			}
+		}
+	}
+	return supported
+}
+
+// matchesFilters reports whether res satisfies the list filters in query.
+// Equality filters compare with the field of the same name, date filters
+// compare by day; resources lacking the field never match. PhoneNumber
+// matches part of the number.
+func matchesFilters(res Resource, query url.Values) bool {
+	for param := range query {
+		m := filterParam.FindStringSubmatch(param)
+		if pagingParams[param] || m == nil {
+			continue
+		}
+		value, ok := res[snakeCase(m[1])]
+		if !ok || value == nil {
+			return false
+		}
+		want := query.Get(param)
+		if param == "PhoneNumber" {
+			// Twilio matches any part of phone numbers
+			if !strings.Contains(fmt.Sprint(value), want) {
+				return false
+			}
+			continue
+		}
+		if m[2] == "" {
+			if fmt.Sprint(value) != want {
+				return false
+			}
+			continue
+		}
+
+		got, err := time.Parse(time.RFC1123Z, fmt.Sprint(value))
+		if err != nil {
+			return false
+		}
+		day, err := time.Parse("2006-01-02", want)
+		if err != nil {
+			return false
+		}
+		// Twilio's date filters are inclusive of the given day
+		if m[2] == "<" && !got.Before(day.Add(24*time.Hour)) {
+			return false
+		} else if m[2] == ">" && got.Before(day) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAvailable reports whether an available phone number, seeded at e.g.
+// "AvailablePhoneNumbers/US/Local", satisfies the search params in query.
+// NearNumber is not supported and matches any number.
+func matchesAvailable(res Resource, query url.Values) bool {
+	number, _ := res["phone_number"].(string)
+	for param := range query {
+		want := query.Get(param)
+		switch param {
+		case "AreaCode":
+			if !strings.HasPrefix(number, "+1"+want) {
+				return false
+			}
+		case "Contains":
+			if !containsPattern(number, want) {
+				return false
+			}
+		case "InRegion", "InPostalCode", "InLocality":
+			if fmt.Sprint(res[snakeCase(strings.TrimPrefix(param, "In"))]) != want {
+				return false
+			}
+		case "NearLatLong":
+			if !near(res, want, query.Get("Distance")) {
+				return false
+			}
+		case "VoiceEnabled", "SmsEnabled", "MmsEnabled", "FaxEnabled":
+			if capability(res, strings.TrimSuffix(param, "Enabled")) != (want == "true") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// keypad maps letters to the digits they are on
+var keypad = strings.NewReplacer(
+	"A", "2", "B", "2", "C", "2", "D", "3", "E", "3", "F", "3",
+	"G", "4", "H", "4", "I", "4", "J", "5", "K", "5", "L", "5",
+	"M", "6", "N", "6", "O", "6", "P", "7", "Q", "7", "R", "7", "S", "7",
+	"T", "8", "U", "8", "V", "8", "W", "9", "X", "9", "Y", "9", "Z", "9",
+)
+
+// containsPattern reports whether number contains pattern, which may have
+// letters for their keypad digits and "*" for any digit
+func containsPattern(number, pattern string) bool {
+	pattern = keypad.Replace(strings.ToUpper(pattern))
+	for start := 0; start+len(pattern) <= len(number); start++ {
+		i := 0
+		for i < len(pattern) && (pattern[i] == number[start+i] ||
+			pattern[i] == '*' && unicode.IsDigit(rune(number[start+i]))) {
+			i++
+		}
+		if i == len(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// near reports whether res is within distance miles, 25 by default, of the
+// "lat,long" coordinates latLong
+func near(res Resource, latLong, distance string) bool {
+	miles, err := strconv.ParseFloat(distance, 64)
+	if err != nil {
+		miles = 25
+	}
+	coords := strings.Split(latLong, ",")
+	if len(coords) != 2 {
+		return false
+	}
+	lat1, err1 := strconv.ParseFloat(coords[0], 64)
+	long1, err2 := strconv.ParseFloat(coords[1], 64)
+	lat2, err3 := strconv.ParseFloat(fmt.Sprint(res["latitude"]), 64)
+	long2, err4 := strconv.ParseFloat(fmt.Sprint(res["longitude"]), 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return false
+	}
+	// an equirectangular approximation is plenty at these distances
+	const earthRadius = 3959 // miles
+	x := (long2 - long1) * math.Cos((lat1+lat2)/2*math.Pi/180)
+	y := lat2 - lat1
+	return earthRadius*math.Sqrt(x*x+y*y)*math.Pi/180 <= miles
+}
+
+// capability reports whether res has the capability, e.g. "Sms". Twilio
+// spells the keys of capabilities in varying case.
+func capability(res Resource, name string) bool {
+	caps, ok := res["capabilities"].(map[string]interface{})
+	if r, isResource := res["capabilities"].(Resource); isResource {
+		caps, ok = r, true
+	}
+	if !ok {
+		return false
+	}
+	for k, v := range caps {
+		if strings.EqualFold(k, name) {
+			return v == true
+		}
+	}
+	return false
+}
+
+var phoneNumber = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+func (s *Server) lookup(r *http.Request) (Resource, *restError) {
+	number := strings.TrimPrefix(r.URL.Path, "/v1/PhoneNumbers/")
+	res, ok := s.lookups[number]
+	if !ok {
+		if !phoneNumber.MatchString(number) {
+			return nil, notFound(r.URL.Path)
+		}
+		res = Resource{
+			"country_code":    "US",
+			"phone_number":    number,
+			"national_format": number,
+			"carrier": Resource{
+				"error_code":          nil,
+				"mobile_country_code": "310",
+				"mobile_network_code": "456",
+				"name":                "utwiltest Wireless",
+				"type":                "mobile",
+			},
+		}
+	}
+	res = copyResource(res)
+	if r.URL.Query().Get("Type") != "carrier" {
+		res["carrier"] = nil
+	}
+	res["url"] = s.LookupURL() + "/PhoneNumbers/" + number
+	return res, nil
+}
@@ -0,0 +1,216 @@
+package utwil
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SignatureHeader is the header Twilio signs its webhook requests with
+const SignatureHeader = "X-Twilio-Signature"
+
+// ErrInvalidSignature is returned for webhook requests that were not signed
+// by Twilio with the expected auth token.
+var ErrInvalidSignature = errors.New("utwil: invalid " + SignatureHeader)
+
+// Signature computes the X-Twilio-Signature of a webhook request to fullURL,
+// the URL as configured with Twilio including its query string, carrying the
+// given POST parameters (nil for GET and JSON requests).
+//
+// Details:
+//
+//	https://www.twilio.com/docs/usage/security#validating-requests
+func Signature(authToken, fullURL string, params url.Values) string {
+	mac := hmac.New(sha1.New, []byte(authToken))
+	io.WriteString(mac, fullURL)
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		values := append([]string(nil), params[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			io.WriteString(mac, k+v)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateSignature reports whether signature was computed by Twilio for a
+// request to fullURL with params. As proxies commonly add or strip default
+// ports, fullURL is also tried with and without its port.
+func ValidateSignature(authToken, fullURL string, params url.Values, signature string) bool {
+	for _, u := range urlVariants(fullURL) {
+		expected := Signature(authToken, u, params)
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBodySHA256 reports whether body hashes to the bodySHA256 query
+// parameter Twilio adds to the URL of webhook requests with JSON bodies.
+// The signature of such requests covers the URL alone.
+func ValidateBodySHA256(fullURL string, body []byte) bool {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return false
+	}
+	expected := u.Query().Get("bodySHA256")
+	if expected == "" {
+		return false
+	}
+	sum := sha256.Sum256(body)
+	return hmac.Equal([]byte(hex.EncodeToString(sum[:])), []byte(strings.ToLower(expected)))
+}
+
+// urlVariants returns fullURL as is, without its port and with the default
+// port of its scheme.
+func urlVariants(fullURL string) []string {
+	variants := []string{fullURL}
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return variants
+	}
+	if u.Port() != "" {
+		u.Host = u.Hostname()
+	} else if u.Scheme == "https" {
+		u.Host = net.JoinHostPort(u.Hostname(), "443")
+	} else if u.Scheme == "http" {
+		u.Host = net.JoinHostPort(u.Hostname(), "80")
+	}
+	return append(variants, u.String())
+}
+
+// Validator validates that incoming webhook requests were signed by Twilio.
+type Validator struct {
+	AuthToken string
+
+	// TrustForwardedHeaders makes the public URL of a request follow the
+	// X-Forwarded-Proto and X-Forwarded-Host headers set by proxies.
+	TrustForwardedHeaders bool
+	// PublicBaseURL, if set, replaces the scheme and host of requests and
+	// prefixes their path, for apps mounted behind a rewriting proxy.
+	PublicBaseURL string
+}
+
+// ValidatorConf configures a passed *utwil.Validator
+type ValidatorConf func(*Validator)
+
+// NewValidator creates a Validator for requests signed with authToken.
+func NewValidator(authToken string, confs ...ValidatorConf) *Validator {
+	v := &Validator{AuthToken: authToken}
+	for _, conf := range confs {
+		conf(v)
+	}
+	return v
+}
+
+// Validator creates a Validator for requests signed with the client's
+// AuthToken.
+//
+// Example:
+//
+//	v := client.Validator(utwil.TrustForwardedHeaders())
+//	http.Handle("/twilio/status", v.Middleware(statusHandler))
+func (c *Client) Validator(confs ...ValidatorConf) *Validator {
+	return NewValidator(c.AuthToken, confs...)
+}
+
+// TrustForwardedHeaders sets Validator.TrustForwardedHeaders
+func TrustForwardedHeaders() ValidatorConf {
+	return func(v *Validator) { v.TrustForwardedHeaders = true }
+}
+
+// PublicBaseURL sets Validator.PublicBaseURL, e.g. "https://example.com/app"
+func PublicBaseURL(baseURL string) ValidatorConf {
+	return func(v *Validator) { v.PublicBaseURL = strings.TrimSuffix(baseURL, "/") }
+}
+
+// PublicURL returns the URL Twilio made r to, which is the URL it signed.
+func (v *Validator) PublicURL(r *http.Request) string {
+	if v.PublicBaseURL != "" {
+		return v.PublicBaseURL + r.URL.RequestURI()
+	}
+
+	scheme, host := "http", r.Host
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if v.TrustForwardedHeaders {
+		if proto := firstHeaderValue(r, "X-Forwarded-Proto"); proto != "" {
+			scheme = proto
+		}
+		if fwdHost := firstHeaderValue(r, "X-Forwarded-Host"); fwdHost != "" {
+			host = fwdHost
+		}
+	}
+	return scheme + "://" + host + r.URL.RequestURI()
+}
+
+// firstHeaderValue returns the first of a comma-separated header's values
+func firstHeaderValue(r *http.Request, header string) string {
+	value := strings.SplitN(r.Header.Get(header), ",", 2)[0]
+	return strings.TrimSpace(value)
+}
+
+// ValidateRequest returns ErrInvalidSignature unless r is a validly signed
+// Twilio request. Form-encoded parameters stay available from r.PostForm, and
+// JSON bodies are restored to be read again.
+func (v *Validator) ValidateRequest(r *http.Request) error {
+	signature := r.Header.Get(SignatureHeader)
+	if signature == "" {
+		return ErrInvalidSignature
+	}
+	fullURL := v.PublicURL(r)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if !ValidateBodySHA256(fullURL, body) ||
+			!ValidateSignature(v.AuthToken, fullURL, nil, signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+	if !ValidateSignature(v.AuthToken, fullURL, r.PostForm, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Middleware wraps next, rejecting requests that fail ValidateRequest with
+// 403 Forbidden.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.ValidateRequest(r); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,85 @@
+package utwil
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Example values from Twilio's security documentation
+var (
+	testAuthToken = "12345"
+	testURL       = "https://mycompany.com/myapp.php?foo=1&bar=2"
+	testParams    = url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+12349013030"},
+		"Digits":  {"1234"},
+		"From":    {"+12349013030"},
+		"To":      {"+18005551212"},
+	}
+	testSignature = "0/KCTR6DLpKmkAf8muzZqo1nDgQ="
+)
+
+func TestSignature(t *testing.T) {
+	if sig := Signature(testAuthToken, testURL, testParams); sig != testSignature {
+		t.Fatalf("expected %s, got %s", testSignature, sig)
+	}
+	if !ValidateSignature(testAuthToken, "https://mycompany.com:443/myapp.php?foo=1&bar=2", testParams, testSignature) {
+		t.Fatalf("signature did not validate with the default port added")
+	}
+	if ValidateSignature("54321", testURL, testParams, testSignature) {
+		t.Fatalf("signature validated with the wrong auth token")
+	}
+}
+
+func TestValidateBodySHA256(t *testing.T) {
+	body := []byte(`{"property": "value", "boolean": true}`)
+	fullURL := "https://mycompany.com/myapp.php?bodySHA256=0a1ff7634d9ab3b95db5c9a2dfe9416e41502b283a80c7cf19632632f96e6620"
+	if !ValidateBodySHA256(fullURL, body) {
+		t.Fatalf("body hash did not validate")
+	}
+	if ValidateBodySHA256(fullURL, []byte(`{}`)) {
+		t.Fatalf("hash of a different body validated")
+	}
+}
+
+func TestValidatorMiddleware(t *testing.T) {
+	client := NewClient("AC123", testAuthToken)
+	handler := client.Validator(TrustForwardedHeaders()).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, r.PostForm.Get("Digits"))
+		}))
+
+	newRequest := func(signature string) *http.Request {
+		r := httptest.NewRequest("POST", "http://10.0.0.1:8080/myapp.php?foo=1&bar=2",
+			strings.NewReader(testParams.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("X-Forwarded-Proto", "https")
+		r.Header.Set("X-Forwarded-Host", "mycompany.com")
+		if signature != "" {
+			r.Header.Set(SignatureHeader, signature)
+		}
+		return r
+	}
+
+	for _, tc := range []struct {
+		signature string
+		status    int
+	}{
+		{testSignature, http.StatusOK},
+		{"", http.StatusForbidden},
+		{"bm90IGEgc2lnbmF0dXJl", http.StatusForbidden},
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(tc.signature))
+		if w.Code != tc.status {
+			t.Fatalf("signature %q: expected %d, got %d", tc.signature, tc.status, w.Code)
+		}
+		if tc.status == http.StatusOK && w.Body.String() != "1234" {
+			t.Fatalf("form was not passed on: %q", w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package utwil
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CallStatus is the status of a call, as reported by Twilio in webhooks and
+// Call.Status.
+type CallStatus string
+
+// Call statuses
+const (
+	CallQueued     CallStatus = "queued"
+	CallRinging    CallStatus = "ringing"
+	CallInProgress CallStatus = "in-progress"
+	CallCompleted  CallStatus = "completed"
+	CallBusy       CallStatus = "busy"
+	CallFailed     CallStatus = "failed"
+	CallNoAnswer   CallStatus = "no-answer"
+	CallCanceled   CallStatus = "canceled"
+)
+
+// MessageStatus is the status of a message, as reported by Twilio in
+// webhooks and Message.Status.
+type MessageStatus string
+
+// Message statuses
+const (
+	MessageAccepted           MessageStatus = "accepted"
+	MessageScheduled          MessageStatus = "scheduled"
+	MessageQueued             MessageStatus = "queued"
+	MessageSending            MessageStatus = "sending"
+	MessageSent               MessageStatus = "sent"
+	MessageFailed             MessageStatus = "failed"
+	MessageDelivered          MessageStatus = "delivered"
+	MessageUndelivered        MessageStatus = "undelivered"
+	MessageReceiving          MessageStatus = "receiving"
+	MessageReceived           MessageStatus = "received"
+	MessageRead               MessageStatus = "read"
+	MessageCanceled           MessageStatus = "canceled"
+	MessagePartiallyDelivered MessageStatus = "partially_delivered"
+)
+
+// Location is the geographic data Twilio includes for the From and To numbers
+// of webhook requests, when available.
+type Location struct {
+	City    string
+	State   string
+	Zip     string
+	Country string
+}
+
+func parseLocation(form url.Values, prefix string) Location {
+	return Location{
+		City:    form.Get(prefix + "City"),
+		State:   form.Get(prefix + "State"),
+		Zip:     form.Get(prefix + "Zip"),
+		Country: form.Get(prefix + "Country"),
+	}
+}
+
+// VoiceRequest is the Go-representation of the parameters Twilio sends to the
+// voice URL of a call, e.g. CallReq.URL, and to the action URLs of TwiML verbs
+// such as <Gather> and <Dial>.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/twiml#twilios-request-to-your-application
+type VoiceRequest struct {
+	AccountSID     string
+	ApplicationSID string
+	APIVersion     string
+	CallSID        string
+	ParentCallSID  string
+	CallStatus     CallStatus
+	Direction      string
+	From           string
+	To             string
+	FromLocation   Location
+	ToLocation     Location
+	ForwardedFrom  string
+	CallerName     string
+	AnsweredBy     string
+
+	// <Gather> results
+	Digits       string
+	SpeechResult string
+	Confidence   float64
+
+	// <Record> results
+	RecordingURL      string
+	RecordingSID      string
+	RecordingDuration int
+
+	// <Dial> results
+	DialCallSID      string
+	DialCallStatus   CallStatus
+	DialCallDuration int
+
+	// Form holds every parameter, including those not parsed above
+	Form url.Values
+}
+
+// ParseVoiceRequest parses the parameters of a Twilio voice webhook request.
+func ParseVoiceRequest(r *http.Request) (*VoiceRequest, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	form := r.Form
+	p := formParser{form: form}
+	req := &VoiceRequest{
+		AccountSID:     form.Get("AccountSid"),
+		ApplicationSID: form.Get("ApplicationSid"),
+		APIVersion:     form.Get("ApiVersion"),
+		CallSID:        form.Get("CallSid"),
+		ParentCallSID:  form.Get("ParentCallSid"),
+		CallStatus:     CallStatus(form.Get("CallStatus")),
+		Direction:      form.Get("Direction"),
+		From:           form.Get("From"),
+		To:             form.Get("To"),
+		FromLocation:   parseLocation(form, "From"),
+		ToLocation:     parseLocation(form, "To"),
+		ForwardedFrom:  form.Get("ForwardedFrom"),
+		CallerName:     form.Get("CallerName"),
+		AnsweredBy:     form.Get("AnsweredBy"),
+
+		Digits:       form.Get("Digits"),
+		SpeechResult: form.Get("SpeechResult"),
+		Confidence:   p.float("Confidence"),
+
+		RecordingURL:      form.Get("RecordingUrl"),
+		RecordingSID:      form.Get("RecordingSid"),
+		RecordingDuration: p.int("RecordingDuration"),
+
+		DialCallSID:      form.Get("DialCallSid"),
+		DialCallStatus:   CallStatus(form.Get("DialCallStatus")),
+		DialCallDuration: p.int("DialCallDuration"),
+
+		Form: form,
+	}
+	return req, p.err
+}
+
+// MediaRef is a media file attached to an inbound message.
+type MediaRef struct {
+	URL         string
+	ContentType string
+}
+
+// MessageRequest is the Go-representation of the parameters Twilio sends to
+// the messaging webhook of a number when it receives a message.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/messaging/guides/webhook-request
+type MessageRequest struct {
+	AccountSID          string
+	MessagingServiceSID string
+	MessageSID          string
+	Status              MessageStatus
+	From                string
+	To                  string
+	FromLocation        Location
+	ToLocation          Location
+	Body                string
+	NumSegments         int
+	NumMedia            int
+	Media               []MediaRef
+	APIVersion          string
+
+	// Form holds every parameter, including those not parsed above
+	Form url.Values
+}
+
+// ParseMessageRequest parses the parameters of a Twilio messaging webhook
+// request, including the MediaUrlN/MediaContentTypeN pairs of MMS. A NumMedia
+// outside of 0 to MaxMediaURLs is rejected as malformed.
+func ParseMessageRequest(r *http.Request) (*MessageRequest, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	form := r.Form
+	p := formParser{form: form}
+	req := &MessageRequest{
+		AccountSID:          form.Get("AccountSid"),
+		MessagingServiceSID: form.Get("MessagingServiceSid"),
+		MessageSID:          form.Get("MessageSid"),
+		Status:              MessageStatus(form.Get("SmsStatus")),
+		From:                form.Get("From"),
+		To:                  form.Get("To"),
+		FromLocation:        parseLocation(form, "From"),
+		ToLocation:          parseLocation(form, "To"),
+		Body:                form.Get("Body"),
+		NumSegments:         p.int("NumSegments"),
+		NumMedia:            p.int("NumMedia"),
+		APIVersion:          form.Get("ApiVersion"),
+		Form:                form,
+	}
+	if req.MessageSID == "" {
+		req.MessageSID = form.Get("SmsMessageSid")
+	}
+	if req.NumMedia < 0 || req.NumMedia > MaxMediaURLs {
+		return nil, fmt.Errorf("utwil: NumMedia %d is out of range 0-%d", req.NumMedia, MaxMediaURLs)
+	}
+	for i := 0; i < req.NumMedia; i++ {
+		req.Media = append(req.Media, MediaRef{
+			URL:         form.Get(fmt.Sprintf("MediaUrl%d", i)),
+			ContentType: form.Get(fmt.Sprintf("MediaContentType%d", i)),
+		})
+	}
+	return req, p.err
+}
+
+// StatusCallback is the Go-representation of the parameters Twilio sends to
+// the StatusCallback URL of a call or message. Only the fields of the
+// resource the callback is about are set.
+//
+// Details:
+//
+//	https://www.twilio.com/docs/voice/api/call-resource#statuscallback
+//	https://www.twilio.com/docs/messaging/guides/track-outbound-message-status
+type StatusCallback struct {
+	AccountSID string
+	From       string
+	To         string
+
+	// Calls
+	CallSID           string
+	CallStatus        CallStatus
+	CallDuration      int
+	CallbackSource    string
+	SequenceNumber    int
+	Timestamp         time.Time
+	AnsweredBy        string
+	RecordingURL      string
+	RecordingSID      string
+	RecordingDuration int
+
+	// Messages
+	MessageSID    string
+	MessageStatus MessageStatus
+	ErrorCode     int
+
+	// Form holds every parameter, including those not parsed above
+	Form url.Values
+}
+
+// ParseStatusCallback parses the parameters of a call or message status
+// callback request.
+func ParseStatusCallback(r *http.Request) (*StatusCallback, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	form := r.Form
+	p := formParser{form: form}
+	cb := &StatusCallback{
+		AccountSID: form.Get("AccountSid"),
+		From:       form.Get("From"),
+		To:         form.Get("To"),
+
+		CallSID:           form.Get("CallSid"),
+		CallStatus:        CallStatus(form.Get("CallStatus")),
+		CallDuration:      p.int("CallDuration"),
+		CallbackSource:    form.Get("CallbackSource"),
+		SequenceNumber:    p.int("SequenceNumber"),
+		Timestamp:         p.time("Timestamp"),
+		AnsweredBy:        form.Get("AnsweredBy"),
+		RecordingURL:      form.Get("RecordingUrl"),
+		RecordingSID:      form.Get("RecordingSid"),
+		RecordingDuration: p.int("RecordingDuration"),
+
+		MessageSID:    form.Get("MessageSid"),
+		MessageStatus: MessageStatus(form.Get("MessageStatus")),
+		ErrorCode:     p.int("ErrorCode"),
+
+		Form: form,
+	}
+	return cb, p.err
+}
+
+// formParser parses optional numeric and time parameters, keeping the first
+// error encountered.
+type formParser struct {
+	form url.Values
+	err  error
+}
+
+func (p *formParser) int(key string) int {
+	value := p.form.Get(key)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("utwil: parsing %s: %s", key, err)
+	}
+	return n
+}
+
+func (p *formParser) float(key string) float64 {
+	value := p.form.Get(key)
+	if value == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("utwil: parsing %s: %s", key, err)
+	}
+	return f
+}
+
+func (p *formParser) time(key string) time.Time {
+	value := p.form.Get(key)
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC1123Z, value)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("utwil: parsing %s: %s", key, err)
+	}
+	return t
+}
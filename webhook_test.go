@@ -0,0 +1,96 @@
+package utwil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newWebhookRequest(form url.Values) *http.Request {
+	r := httptest.NewRequest("POST", "https://example.com/twilio", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestParseVoiceRequest(t *testing.T) {
+	req, err := ParseVoiceRequest(newWebhookRequest(url.Values{
+		"CallSid":        {"CA123"},
+		"CallStatus":     {"in-progress"},
+		"From":           {"+15551231234"},
+		"FromCity":       {"SAN FRANCISCO"},
+		"Digits":         {"42"},
+		"Confidence":     {"0.9"},
+		"DialCallSid":    {"CA456"},
+		"CustomParam":    {"kept"},
+		"DialCallStatus": {"no-answer"},
+	}))
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if req.CallSID != "CA123" || req.CallStatus != CallInProgress ||
+		req.FromLocation.City != "SAN FRANCISCO" || req.Digits != "42" ||
+		req.Confidence != 0.9 || req.DialCallStatus != CallNoAnswer ||
+		req.Form.Get("CustomParam") != "kept" {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+}
+
+func TestParseMessageRequest(t *testing.T) {
+	req, err := ParseMessageRequest(newWebhookRequest(url.Values{
+		"MessageSid":        {"MM123"},
+		"SmsStatus":         {"received"},
+		"Body":              {"Hello, world!"},
+		"NumMedia":          {"2"},
+		"MediaUrl0":         {"https://api.twilio.com/media/ME0"},
+		"MediaContentType0": {"image/png"},
+		"MediaUrl1":         {"https://api.twilio.com/media/ME1"},
+		"MediaContentType1": {"image/jpeg"},
+	}))
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if req.MessageSID != "MM123" || req.Status != MessageReceived || len(req.Media) != 2 ||
+		req.Media[1] != (MediaRef{"https://api.twilio.com/media/ME1", "image/jpeg"}) {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+
+	_, err = ParseMessageRequest(newWebhookRequest(url.Values{"NumMedia": {"two"}}))
+	if err == nil {
+		t.Fatalf("malformed NumMedia was accepted")
+	}
+	for _, numMedia := range []string{"-1", "11", "100000000"} {
+		_, err = ParseMessageRequest(newWebhookRequest(url.Values{"NumMedia": {numMedia}}))
+		if err == nil {
+			t.Fatalf("NumMedia=%s was accepted", numMedia)
+		}
+	}
+}
+
+func TestParseStatusCallback(t *testing.T) {
+	cb, err := ParseStatusCallback(newWebhookRequest(url.Values{
+		"MessageSid":    {"SM123"},
+		"MessageStatus": {"undelivered"},
+		"ErrorCode":     {"30003"},
+	}))
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if cb.MessageStatus != MessageUndelivered || cb.ErrorCode != 30003 {
+		t.Fatalf("unexpected callback: %+v", cb)
+	}
+
+	cb, err = ParseStatusCallback(newWebhookRequest(url.Values{
+		"CallSid":      {"CA123"},
+		"CallStatus":   {"completed"},
+		"CallDuration": {"61"},
+		"Timestamp":    {"Mon, 16 Aug 2010 03:45:01 +0000"},
+	}))
+	if err != nil {
+		t.Fatalf("Failed: %s", err.Error())
+	}
+	if cb.CallStatus != CallCompleted || cb.CallDuration != 61 || cb.Timestamp.Year() != 2010 {
+		t.Fatalf("unexpected callback: %+v", cb)
+	}
+}